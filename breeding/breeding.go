@@ -0,0 +1,332 @@
+// Package breeding answers questions about breeding Pokémon: whether two
+// pokeapi.PokemonSpecies can produce an egg, how long that egg takes to hatch,
+// and which egg moves a species can inherit - including those that need to be
+// passed down a chain of several parents first.
+package breeding
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// The names of the pokeapi.EggGroup s with special breeding rules.
+const (
+	EggGroupNoEggs = "no-eggs" // Species in this group cannot breed at all.
+	EggGroupDitto  = "ditto"   // Species in this group can breed with any other that can breed.
+)
+
+// Genderless is the pokeapi.PokemonSpecies.GenderRate of a genderless species.
+const Genderless = -1
+
+// DefaultMaxChain is the longest chain of parents EggMoves searches through when
+// Opts.MaxChain is not set.
+const DefaultMaxChain = 3
+
+func inEggGroup(s *pokeapi.PokemonSpecies, group string) bool {
+	return slices.ContainsFunc(
+		s.EggGroups,
+		func(eg pokeapi.NamedAPIResource[pokeapi.EggGroup]) bool { return eg.Name == group },
+	)
+}
+
+func canBeMale(s *pokeapi.PokemonSpecies) bool {
+	return s.GenderRate != Genderless && s.GenderRate < 8
+}
+
+func canBeFemale(s *pokeapi.PokemonSpecies) bool {
+	return s.GenderRate != Genderless && s.GenderRate > 0
+}
+
+// Compatible reports whether the two pokeapi.PokemonSpecies can produce an egg
+// together.
+//
+// Neither species may be in EggGroupNoEggs. A species in EggGroupDitto can
+// breed with any other species - but not with another in EggGroupDitto.
+// Otherwise the species must share an egg group, and one must be able to be
+// male while the other can be female; so genderless species only breed with
+// those in EggGroupDitto.
+func Compatible(a, b *pokeapi.PokemonSpecies) bool {
+	if inEggGroup(a, EggGroupNoEggs) || inEggGroup(b, EggGroupNoEggs) {
+		return false
+	}
+
+	aDitto, bDitto := inEggGroup(a, EggGroupDitto), inEggGroup(b, EggGroupDitto)
+	if aDitto || bDitto {
+		return aDitto != bDitto
+	}
+
+	shared := slices.ContainsFunc(
+		a.EggGroups,
+		func(eg pokeapi.NamedAPIResource[pokeapi.EggGroup]) bool { return inEggGroup(b, eg.Name) },
+	)
+	return shared && ((canBeMale(a) && canBeFemale(b)) || (canBeFemale(a) && canBeMale(b)))
+}
+
+// CycleSteps returns the number of steps in each egg cycle in the given
+// generation, or pokeapi.LatestGeneration. Generation-i had no breeding, so
+// returns 0. pokeapi.ErrInvalidInput is returned for any other generation.
+func CycleSteps(gen int) (int, error) {
+	switch gen {
+	case 1:
+		return 0, nil
+	case 4:
+		return 255, nil
+	case 5, 6, 8:
+		return 257, nil
+	case 2, 3, 7:
+		return 256, nil
+	case 9, pokeapi.LatestGeneration:
+		return 128, nil
+	default:
+		return 0, fmt.Errorf("%w: generation %d", pokeapi.ErrInvalidInput, gen)
+	}
+}
+
+// HatchSteps returns the number of steps an egg of the pokeapi.PokemonSpecies
+// takes to hatch in the given generation, without bonuses such as those from
+// the Flame Body ability. pokeapi.ErrInvalidInput is returned if CycleSteps
+// does not know the generation.
+func HatchSteps(s *pokeapi.PokemonSpecies, gen int) (int, error) {
+	steps, err := CycleSteps(gen)
+	if err != nil {
+		return 0, err
+	}
+	return steps * (s.HatchCounter + 1), nil
+}
+
+// Opts configure EggMoves.
+type Opts struct {
+	// The maximum number of resources to retrieve at once. Defaults to 1.
+	Concurrency int
+
+	// The maximum number of parents in a chain, including the parent of the
+	// species itself. Defaults to DefaultMaxChain.
+	MaxChain int
+}
+
+// An EggMove is a pokeapi.Move a species can learn as an egg move, and how it
+// can inherit it.
+type EggMove struct {
+	Move pokeapi.NamedAPIResource[pokeapi.Move] `json:"move"`
+
+	// The names of the species that can pass the move on directly, as they learn
+	// it other than as an egg move. Ordered by name.
+	Parents []string `json:"parents"`
+
+	// The shortest chain of species that passes the move on. Chain[0] is a parent
+	// of the species itself, and each species is a parent of the one before it.
+	// The last species learns the move other than as an egg move.
+	//
+	// If the move has Parents, Chain holds the first of them. If no chain of at
+	// most Opts.MaxChain parents was found, Chain is empty.
+	Chain []string `json:"chain"`
+}
+
+// breeder retrieves & remembers the resources needed by EggMoves.
+type breeder struct {
+	c            *pokeapi.Client
+	versionGroup string
+	concurrency  int
+
+	species   map[string]*pokeapi.PokemonSpecies
+	learnsets map[string]pokeapi.Learnset // species name => Learnset of its default Pokemon.
+	fathers   map[string][]string         // species name => names of the species that can father its eggs.
+}
+
+// load retrieves the species that have not been retrieved yet.
+func (b *breeder) load(ctx context.Context, refs []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]) error {
+	refs = slices.DeleteFunc(
+		slices.Clone(refs),
+		func(r pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]) bool { _, ok := b.species[r.Name]; return ok },
+	)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	species, err := pokeapi.GetAll(ctx, b.c, refs, b.concurrency)
+	if err != nil {
+		return fmt.Errorf("getting pokemon species: %w", err)
+	}
+	return b.add(ctx, species...)
+}
+
+// add retrieves the default pokeapi.Pokemon of each species, and remembers both.
+func (b *breeder) add(ctx context.Context, species ...*pokeapi.PokemonSpecies) error {
+	var refs []pokeapi.NamedAPIResource[pokeapi.Pokemon]
+	for _, s := range species {
+		for _, v := range s.Varieties {
+			if v.IsDefault {
+				refs = append(refs, v.Pokemon)
+				break
+			}
+		}
+	}
+	pokemon, err := pokeapi.GetAll(ctx, b.c, refs, b.concurrency)
+	if err != nil {
+		return fmt.Errorf("getting pokemon: %w", err)
+	}
+	defaults := make(map[string]*pokeapi.Pokemon, len(pokemon))
+	for _, p := range pokemon {
+		defaults[p.Species.Name] = p
+	}
+
+	for _, s := range species {
+		b.species[s.Name] = s
+		if p, ok := defaults[s.Name]; ok {
+			b.learnsets[s.Name] = p.Learnset(b.versionGroup)
+		}
+	}
+	return nil
+}
+
+// fathersOf returns the names of the species that can father eggs of the named
+// species, which must already be loaded. Species in EggGroupDitto are excluded,
+// as they cannot pass on moves.
+func (b *breeder) fathersOf(ctx context.Context, name string) ([]string, error) {
+	if f, ok := b.fathers[name]; ok {
+		return f, nil
+	}
+
+	s := b.species[name]
+	var refs []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]
+	for _, ref := range s.EggGroups {
+		if ref.Name == EggGroupNoEggs || ref.Name == EggGroupDitto {
+			continue
+		}
+		eg, err := ref.Get(ctx, b.c)
+		if err != nil {
+			return nil, fmt.Errorf("getting egg group %q: %w", ref.Name, err)
+		}
+		refs = append(refs, eg.PokemonSpecies...)
+	}
+	if err := b.load(ctx, refs); err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, ref := range refs {
+		f := b.species[ref.Name]
+		if f.Name != s.Name && canBeMale(f) && Compatible(s, f) && !slices.Contains(res, f.Name) {
+			res = append(res, f.Name)
+		}
+	}
+	slices.Sort(res)
+
+	b.fathers[name] = res
+	return res, nil
+}
+
+// learns reports whether the named species learns the move in the version
+// group, and whether only as an egg move.
+func (b *breeder) learns(species, move string) (learns, eggOnly bool) {
+	for _, lm := range b.learnsets[species].All() {
+		if lm.Move.Name != move {
+			continue
+		}
+		if lm.Method != pokeapi.LearnMethodEgg {
+			return true, false
+		}
+		learns = true
+	}
+	return learns, learns
+}
+
+// chain searches breadth-first for the shortest chain of parents that passes
+// the move on to the named species.
+func (b *breeder) chain(ctx context.Context, species, move string, maxChain int) ([]string, error) {
+	var (
+		visited  = map[string]bool{species: true}
+		frontier = [][]string{{species}}
+	)
+	for range maxChain {
+		var next [][]string
+		for _, path := range frontier {
+			fathers, err := b.fathersOf(ctx, path[len(path)-1])
+			if err != nil {
+				return nil, err
+			}
+
+			for _, f := range fathers {
+				if visited[f] {
+					continue
+				}
+				visited[f] = true
+
+				learns, eggOnly := b.learns(f, move)
+				switch {
+				case learns && !eggOnly:
+					return append(slices.Clone(path[1:]), f), nil
+				case learns:
+					next = append(next, append(slices.Clone(path), f))
+				}
+			}
+		}
+		frontier = next
+	}
+	return nil, nil
+}
+
+// EggMoves returns the egg moves the pokeapi.PokemonSpecies can learn in the
+// named pokeapi.VersionGroup, taken from its default pokeapi.Pokemon, and the
+// species it can inherit each from. Moves are ordered by name.
+//
+// Potential parents are discovered by walking pokeapi.EggGroup.PokemonSpecies,
+// which means retrieving many resources - provide the pokeapi.Client with a
+// pokeapi.Cache so that repeated calls are cheap. Only species that can be male
+// are considered as parents, as only fathers pass egg moves on to other species.
+//
+// A nil Opts uses the defaults.
+func EggMoves(
+	ctx context.Context,
+	c *pokeapi.Client,
+	species *pokeapi.PokemonSpecies,
+	versionGroup string,
+	opts *Opts,
+) ([]EggMove, error) {
+	var o Opts
+	if opts != nil {
+		o = *opts
+	}
+	o.MaxChain = cmp.Or(o.MaxChain, DefaultMaxChain)
+
+	b := &breeder{
+		c:            c,
+		versionGroup: versionGroup,
+		concurrency:  o.Concurrency,
+		species:      map[string]*pokeapi.PokemonSpecies{},
+		learnsets:    map[string]pokeapi.Learnset{},
+		fathers:      map[string][]string{},
+	}
+	if err := b.add(ctx, species); err != nil {
+		return nil, err
+	}
+
+	fathers, err := b.fathersOf(ctx, species.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []EggMove
+	for _, lm := range b.learnsets[species.Name].Moves[pokeapi.LearnMethodEgg] {
+		em := EggMove{Move: lm.Move}
+		for _, f := range fathers {
+			if learns, eggOnly := b.learns(f, lm.Move.Name); learns && !eggOnly {
+				em.Parents = append(em.Parents, f)
+			}
+		}
+
+		if len(em.Parents) != 0 {
+			em.Chain = em.Parents[:1:1]
+		} else if em.Chain, err = b.chain(ctx, species.Name, lm.Move.Name, o.MaxChain); err != nil {
+			return nil, err
+		}
+		res = append(res, em)
+	}
+
+	slices.SortFunc(res, func(a, b EggMove) int { return cmp.Compare(a.Move.Name, b.Move.Name) })
+	return slices.CompactFunc(res, func(a, b EggMove) bool { return a.Move.Name == b.Move.Name }), nil
+}
@@ -0,0 +1,198 @@
+package breeding_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/breeding"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+func eggGroups(names ...string) []pokeapi.NamedAPIResource[pokeapi.EggGroup] {
+	res := make([]pokeapi.NamedAPIResource[pokeapi.EggGroup], len(names))
+	for i, n := range names {
+		res[i] = pokeapi.NamedAPIResource[pokeapi.EggGroup]{Name: n}
+	}
+	return res
+}
+
+func species(name string, genderRate int, groups ...string) *pokeapi.PokemonSpecies {
+	return &pokeapi.PokemonSpecies{
+		NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+		GenderRate:      genderRate,
+		EggGroups:       eggGroups(groups...),
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	t.Parallel()
+
+	var (
+		eevee     = species("eevee", 1, "ground")
+		pikachu   = species("pikachu", 4, "ground", "fairy")
+		nidoranF  = species("nidoran-f", 8, "monster", "ground")
+		tauros    = species("tauros", 0, "ground")
+		magnemite = species("magnemite", breeding.Genderless, "mineral")
+		ditto     = species("ditto", breeding.Genderless, breeding.EggGroupDitto)
+		pichu     = species("pichu", 4, breeding.EggGroupNoEggs)
+		clefairy  = species("clefairy", 6, "fairy")
+	)
+
+	for _, tc := range []struct {
+		a, b *pokeapi.PokemonSpecies
+		want bool
+	}{
+		{a: eevee, b: pikachu, want: true},
+		{a: eevee, b: eevee, want: true},
+		{a: nidoranF, b: pikachu, want: true},
+		{a: nidoranF, b: nidoranF, want: false},
+		{a: tauros, b: eevee, want: true},
+		{a: tauros, b: tauros, want: false},
+		{a: eevee, b: clefairy, want: false},
+		{a: magnemite, b: magnemite, want: false},
+		{a: magnemite, b: ditto, want: true},
+		{a: ditto, b: eevee, want: true},
+		{a: ditto, b: ditto, want: false},
+		{a: pichu, b: pikachu, want: false},
+		{a: pichu, b: ditto, want: false},
+	} {
+		if got := breeding.Compatible(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s & %s: want %t; got %t", tc.a.Name, tc.b.Name, tc.want, got)
+		}
+	}
+}
+
+func TestHatchSteps(t *testing.T) {
+	t.Parallel()
+
+	eevee := &pokeapi.PokemonSpecies{HatchCounter: 35}
+	for _, tc := range []struct{ gen, want int }{
+		{gen: 1, want: 0},
+		{gen: 2, want: 9216},
+		{gen: 4, want: 9180},
+		{gen: 5, want: 9252},
+		{gen: 8, want: 9252},
+		{gen: 9, want: 4608},
+		{gen: pokeapi.LatestGeneration, want: 4608},
+	} {
+		if got, err := breeding.HatchSteps(eevee, tc.gen); got != tc.want || err != nil {
+			t.Errorf("generation %d: want (%d, nil); got (%d, %v)", tc.gen, tc.want, got, err)
+		}
+	}
+
+	for _, gen := range []int{-3, 10, 42} {
+		if got, err := breeding.HatchSteps(eevee, gen); !errors.Is(err, pokeapi.ErrInvalidInput) {
+			t.Errorf("generation %d: want (0, ErrInvalidInput); got (%d, %v)", gen, got, err)
+		}
+	}
+}
+
+func TestEggMoves(t *testing.T) {
+	t.Parallel()
+
+	ts, c := pokeapitest.NewServer(t)
+
+	const vg = "ruby-sapphire"
+	groups := map[string]*pokeapi.EggGroup{}
+
+	// add serves a species, and its default Pokémon learning each move by the
+	// given method.
+	add := func(name string, genderRate int, groupNames []string, moves map[string]string) *pokeapi.PokemonSpecies {
+		s := species(name, genderRate, groupNames...)
+		for i, g := range groupNames {
+			path := "/egg-group/" + g + "/"
+			s.EggGroups[i].URL = ts.URL + path
+			if groups[g] == nil {
+				groups[g] = &pokeapi.EggGroup{NamedIdentifier: pokeapi.NamedIdentifier{Name: g}}
+				ts.Add(path, groups[g])
+			}
+			groups[g].PokemonSpecies = append(
+				groups[g].PokemonSpecies,
+				pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+					APIResource: pokeapi.APIResource[pokeapi.PokemonSpecies]{URL: ts.URL + "/pokemon-species/" + name + "/"},
+					Name:        name,
+				},
+			)
+		}
+
+		p := &pokeapi.Pokemon{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+			Species:         pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{Name: name},
+		}
+		for m, method := range moves {
+			p.Moves = append(
+				p.Moves,
+				pokeapi.PokemonMove{
+					Move: pokeapi.NamedAPIResource[pokeapi.Move]{Name: m},
+					VersionGroupDetails: []pokeapi.PokemonMoveVersion{
+						{
+							MoveLearnMethod: pokeapi.NamedAPIResource[pokeapi.MoveLearnMethod]{Name: method},
+							VersionGroup:    pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: vg},
+						},
+					},
+				},
+			)
+		}
+		ts.Add("/pokemon/"+name+"/", p)
+
+		s.Varieties = []pokeapi.PokemonSpeciesVariety{
+			{
+				IsDefault: true,
+				Pokemon: pokeapi.NamedAPIResource[pokeapi.Pokemon]{
+					APIResource: pokeapi.APIResource[pokeapi.Pokemon]{URL: ts.URL + "/pokemon/" + name + "/"},
+					Name:        name,
+				},
+			},
+		}
+		ts.Add("/pokemon-species/"+name+"/", s)
+		return s
+	}
+
+	eevee := add(
+		"eevee", 1, []string{"ground"},
+		map[string]string{
+			"charm":      pokeapi.LearnMethodEgg,
+			"wish":       pokeapi.LearnMethodEgg,
+			"fake-tears": pokeapi.LearnMethodEgg,
+			"bite":       pokeapi.LearnMethodLevelUp,
+		},
+	)
+	add("pikachu", 4, []string{"ground", "fairy"}, map[string]string{"charm": pokeapi.LearnMethodLevelUp})
+	add("nidoran-f", 8, []string{"monster", "ground"}, map[string]string{"fake-tears": pokeapi.LearnMethodLevelUp})
+	add("ponyta", 4, []string{"ground"}, map[string]string{"wish": pokeapi.LearnMethodEgg, "charm": pokeapi.LearnMethodMachine})
+	add("skitty", 6, []string{"ground", "fairy"}, map[string]string{"wish": pokeapi.LearnMethodEgg})
+	add("clefairy", 6, []string{"fairy"}, map[string]string{"wish": pokeapi.LearnMethodLevelUp})
+
+	got, err := breeding.EggMoves(context.Background(), c, eevee, vg, &breeding.Opts{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	want := []breeding.EggMove{
+		{
+			Move:    pokeapi.NamedAPIResource[pokeapi.Move]{Name: "charm"},
+			Parents: []string{"pikachu", "ponyta"},
+			Chain:   []string{"pikachu"},
+		},
+		// nidoran-f is female-only, so cannot pass fake-tears on.
+		{Move: pokeapi.NamedAPIResource[pokeapi.Move]{Name: "fake-tears"}},
+		{
+			Move:  pokeapi.NamedAPIResource[pokeapi.Move]{Name: "wish"},
+			Chain: []string{"skitty", "clefairy"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+
+	got, err = breeding.EggMoves(context.Background(), c, eevee, vg, &breeding.Opts{MaxChain: 1})
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	if wish := got[2]; len(wish.Chain) != 0 {
+		t.Errorf("want no chain for wish within 1 parent; got %v", wish.Chain)
+	}
+}
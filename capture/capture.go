@@ -0,0 +1,323 @@
+// Package capture calculates the odds of catching a Pokémon from its
+// pokeapi.PokemonSpecies.CaptureRate, its HP, any status ailment and the Poké
+// Ball thrown, using the formula from a given generation.
+//
+// Calculate gives the exact odds of a single throw. Simulate estimates how many
+// throws a catch takes, which is useful when a plan involves several throws.
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// The names of the pokeapi.MoveAilment s that affect the capture rate.
+const (
+	None      = "none"
+	Sleep     = "sleep"
+	Freeze    = "freeze"
+	Paralysis = "paralysis"
+	Poison    = "poison"
+	Burn      = "burn"
+)
+
+// The names of the pokeapi.Item s in the StandardBallsCategory.
+const (
+	PokeBall    = "poke-ball"
+	GreatBall   = "great-ball"
+	UltraBall   = "ultra-ball"
+	MasterBall  = "master-ball"
+	PremierBall = "premier-ball"
+)
+
+// StandardBallsCategory is the name of the pokeapi.ItemCategory holding the
+// balls with a fixed modifier.
+const StandardBallsCategory = "standard-balls"
+
+// StandardBalls holds the modifier of each ball in the StandardBallsCategory.
+// The Master Ball always succeeds, regardless of its modifier.
+var StandardBalls = map[string]float64{
+	PokeBall:    1,
+	GreatBall:   1.5,
+	UltraBall:   2,
+	MasterBall:  255,
+	PremierBall: 1,
+}
+
+// ErrUnknownBall is returned when a ball's modifier is not known. Provide
+// Opts.BallModifier to use it anyway. pokeapi.ErrInvalidInput is returned when
+// HP is out of range, or a generation is not supported.
+var ErrUnknownBall = errors.New("unknown ball")
+
+// BallModifier returns the modifier of the pokeapi.Item, which must be in the
+// StandardBallsCategory.
+func BallModifier(i *pokeapi.Item) (float64, error) {
+	if i.Category.Name != StandardBallsCategory {
+		return 0, fmt.Errorf("%w: %q is in category %q, not %q", ErrUnknownBall, i.Name, i.Category.Name, StandardBallsCategory)
+	}
+	m, ok := StandardBalls[i.Name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownBall, i.Name)
+	}
+	return m, nil
+}
+
+// StatusModifier returns the capture rate multiplier for the named
+// pokeapi.MoveAilment in the given generation, from generation-iii onwards.
+// Ailments other than those listed by this package have no effect.
+func StatusModifier(ailment string, gen int) float64 {
+	switch ailment {
+	case Sleep, Freeze:
+		if gen == pokeapi.LatestGeneration || gen >= 5 {
+			return 2.5
+		}
+		return 2
+	case Paralysis, Poison, Burn:
+		return 1.5
+	default:
+		return 1
+	}
+}
+
+// Opts configure a throw.
+type Opts struct {
+	// The ID of the Generation to use the formula from - 1 for generation-i and
+	// so on - or pokeapi.LatestGeneration. Generations after generation-vi use
+	// its formula, without their situational modifiers.
+	Generation int
+
+	// The name of the pokeapi.MoveAilment the Pokémon is suffering from, if any.
+	Status string
+
+	// The name of the ball thrown, which must be one of the StandardBalls.
+	// Defaults to PokeBall.
+	Ball string
+
+	// Overrides the modifier of the ball thrown, for balls outside the
+	// StandardBalls - such as a Net Ball against a water-type (3.5). Ignored in
+	// generation-i, which used a different system.
+	BallModifier float64
+}
+
+// A Result holds the odds of a single throw.
+type Result struct {
+	// The probability the throw catches the Pokémon, in [0, 1].
+	Probability float64
+
+	// The number of shake checks made, and the probability each one passes. A
+	// throw catches the Pokémon if every shake check passes. Generations before
+	// generation-iii make no shake checks, so Shakes is 0.
+	Shakes           int
+	ShakeProbability float64
+}
+
+// Calculate returns the odds of catching the pokeapi.PokemonSpecies with a
+// single throw, given its current & maximum HP. A nil Opts throws a Poké Ball
+// at a healthy Pokémon in the latest generation.
+func Calculate(species *pokeapi.PokemonSpecies, currentHP, maxHP int, opts *Opts) (Result, error) {
+	var o Opts
+	if opts != nil {
+		o = *opts
+	}
+	if o.Ball == "" {
+		o.Ball = PokeBall
+	}
+	if maxHP < 1 || currentHP < 1 || currentHP > maxHP {
+		return Result{}, fmt.Errorf("%w: hp %d/%d", pokeapi.ErrInvalidInput, currentHP, maxHP)
+	}
+	if o.Generation < pokeapi.LatestGeneration {
+		return Result{}, fmt.Errorf("%w: generation %d", pokeapi.ErrInvalidInput, o.Generation)
+	}
+
+	ball := o.BallModifier
+	if ball == 0 {
+		m, ok := StandardBalls[o.Ball]
+		if !ok {
+			return Result{}, fmt.Errorf("%w: %q", ErrUnknownBall, o.Ball)
+		}
+		ball = m
+	}
+
+	if o.Ball == MasterBall {
+		return Result{Probability: 1, ShakeProbability: 1, Shakes: shakesIn(o.Generation)}, nil
+	}
+
+	rate := int(species.CaptureRate)
+	switch gen := o.Generation; {
+	case gen == 1:
+		return gen1(rate, currentHP, maxHP, o)
+	case gen == 2:
+		return gen2(rate, currentHP, maxHP, ball, o.Status), nil
+	case gen == 3 || gen == 4:
+		a := modifiedRate(rate, currentHP, maxHP, ball) * StatusModifier(o.Status, gen)
+		return shakes(a, 4, 1048560/math.Sqrt(math.Sqrt(16711680/a))), nil
+	case gen == 5:
+		a := modifiedRate(rate, currentHP, maxHP, ball) * StatusModifier(o.Status, gen)
+		return shakes(a, 3, 65536/math.Pow(255/a, 0.25)), nil
+	default:
+		a := modifiedRate(rate, currentHP, maxHP, ball) * StatusModifier(o.Status, gen)
+		return shakes(a, 4, 65536/math.Pow(255/a, 3.0/16)), nil
+	}
+}
+
+func shakesIn(gen int) int {
+	switch {
+	case gen == 1 || gen == 2:
+		return 0
+	case gen == 5:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// modifiedRate is the capture rate modified by HP and the ball, as used from
+// generation-iii onwards.
+func modifiedRate(rate, currentHP, maxHP int, ball float64) float64 {
+	return float64(3*maxHP-2*currentHP) * float64(rate) * ball / float64(3*maxHP)
+}
+
+// shakes turns the modified rate a and the shake check threshold b (out of
+// 65536) into a Result.
+func shakes(a float64, n int, b float64) Result {
+	if a >= 255 {
+		return Result{Probability: 1, Shakes: n, ShakeProbability: 1}
+	}
+	p := min(b/65536, 1)
+	return Result{Probability: math.Pow(p, float64(n)), Shakes: n, ShakeProbability: p}
+}
+
+// gen1 implements the generation-i algorithm, which draws a random number
+// bounded by the ball and compares it against the status and capture rate
+// before a final check against the Pokémon's HP.
+func gen1(rate, currentHP, maxHP int, o Opts) (Result, error) {
+	var bound, divisor int
+	switch o.Ball {
+	case PokeBall:
+		bound, divisor = 255, 12
+	case GreatBall:
+		bound, divisor = 200, 8
+	case UltraBall:
+		bound, divisor = 150, 12
+	default:
+		return Result{}, fmt.Errorf("%w: %q in generation 1", ErrUnknownBall, o.Ball)
+	}
+
+	var status int
+	switch o.Status {
+	case Sleep, Freeze:
+		status = 25
+	case Paralysis, Poison, Burn:
+		status = 12
+	}
+
+	hpFactor := min(maxHP*255/divisor/max(currentHP/4, 1), 255)
+
+	var caught float64
+	for r1 := 0; r1 <= bound; r1++ {
+		switch {
+		case r1 < status:
+			caught++
+		case r1-status > rate:
+			// breaks free.
+		default:
+			caught += float64(hpFactor+1) / 256
+		}
+	}
+	return Result{Probability: caught / float64(bound+1)}, nil
+}
+
+// gen2 implements the generation-ii algorithm. A bug in those games means only
+// sleep and freeze affect the capture rate.
+func gen2(rate, currentHP, maxHP int, ball float64, status string) Result {
+	a := max(int(float64(3*maxHP-2*currentHP)*float64(rate)*ball)/(3*maxHP), 1)
+	if status == Sleep || status == Freeze {
+		a += 10
+	}
+	a = min(a, 255)
+	return Result{Probability: float64(a+1) / 256}
+}
+
+// An Estimate summarises how many throws it took to catch a Pokémon over many
+// simulated attempts.
+type Estimate struct {
+	Trials int
+
+	MeanThrows   float64
+	MedianThrows int
+	P90Throws    int // 90% of trials catch the Pokémon within this many throws.
+
+	// The number of trials that ran out of throws without catching the Pokémon.
+	// These are excluded from the figures above.
+	Escaped int
+}
+
+// Simulate estimates the number of throws needed to catch a Pokémon with the
+// odds in the Result, by simulating trials attempts of up to maxThrows each.
+// Each throw makes every shake check of the Result in turn. The HP and status
+// of the Pokémon are assumed not to change between throws.
+//
+// A nil rand.Rand uses the top-level functions of math/rand/v2.
+func Simulate(r Result, trials, maxThrows int, rng *rand.Rand) Estimate {
+	float := rand.Float64
+	if rng != nil {
+		float = rng.Float64
+	}
+
+	throw := func() bool {
+		if r.Shakes == 0 {
+			return float() < r.Probability
+		}
+		for range r.Shakes {
+			if float() >= r.ShakeProbability {
+				return false
+			}
+		}
+		return true
+	}
+
+	var (
+		est    = Estimate{Trials: trials}
+		throws []int
+	)
+	for range trials {
+		caught := false
+		for n := 1; n <= maxThrows; n++ {
+			if throw() {
+				throws, caught = append(throws, n), true
+				break
+			}
+		}
+		if !caught {
+			est.Escaped++
+		}
+	}
+	if len(throws) == 0 {
+		return est
+	}
+
+	slices.Sort(throws)
+	total := 0
+	for _, n := range throws {
+		total += n
+	}
+	est.MeanThrows = float64(total) / float64(len(throws))
+	est.MedianThrows = throws[len(throws)/2]
+	est.P90Throws = throws[min(len(throws)*9/10, len(throws)-1)]
+	return est
+}
+
+// ExpectedThrows returns the exact expected number of throws needed to catch a
+// Pokémon with the odds in the Result, assuming unlimited throws. It returns
+// +Inf if the Pokémon cannot be caught.
+func (r Result) ExpectedThrows() float64 {
+	if r.Probability <= 0 {
+		return math.Inf(1)
+	}
+	return 1 / r.Probability
+}
@@ -0,0 +1,129 @@
+package capture_test
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/capture"
+)
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-4 }
+
+func TestCalculate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		bulbasaur = &pokeapi.PokemonSpecies{CaptureRate: 45}
+		rattata   = &pokeapi.PokemonSpecies{CaptureRate: 255}
+	)
+
+	for _, tc := range []struct {
+		name       string
+		species    *pokeapi.PokemonSpecies
+		current    int
+		opts       *capture.Opts
+		want       float64
+		wantShakes int
+	}{
+		{name: "generation-i", species: bulbasaur, current: 100, opts: &capture.Opts{Generation: 1}, want: 46.0 / 256 * 86 / 256},
+		{name: "generation-ii", species: bulbasaur, current: 100, opts: &capture.Opts{Generation: 2}, want: 16.0 / 256},
+		{name: "generation-iii", species: bulbasaur, current: 100, opts: &capture.Opts{Generation: 3}, want: 0.058820, wantShakes: 4},
+		{name: "generation-v", species: bulbasaur, current: 100, opts: &capture.Opts{Generation: 5}, want: 0.119444, wantShakes: 3},
+		{name: "latest", species: bulbasaur, current: 100, opts: nil, want: 0.119444, wantShakes: 4},
+		{name: "master ball", species: bulbasaur, current: 100, opts: &capture.Opts{Ball: capture.MasterBall}, want: 1, wantShakes: 4},
+		{
+			name:       "guaranteed once the modified rate reaches 255",
+			species:    rattata,
+			current:    1,
+			opts:       &capture.Opts{Ball: capture.UltraBall, Status: capture.Sleep},
+			want:       1,
+			wantShakes: 4,
+		},
+	} {
+		got, err := capture.Calculate(tc.species, tc.current, 100, tc.opts)
+		if err != nil || !approx(got.Probability, tc.want) || got.Shakes != tc.wantShakes {
+			t.Errorf("%s: want (%.6f, %d shakes, nil); got (%.6f, %d shakes, %v)", tc.name, tc.want, tc.wantShakes, got.Probability, got.Shakes, err)
+		}
+	}
+
+	t.Run(
+		"improves with lower hp, status and better balls",
+		func(t *testing.T) {
+			t.Parallel()
+
+			for _, gen := range []int{1, 2, 3, 5, pokeapi.LatestGeneration} {
+				base, _ := capture.Calculate(bulbasaur, 100, 100, &capture.Opts{Generation: gen})
+				for name, opts := range map[string]*capture.Opts{
+					"low hp":     {Generation: gen},
+					"asleep":     {Generation: gen, Status: capture.Sleep},
+					"ultra ball": {Generation: gen, Ball: capture.UltraBall},
+				} {
+					hp := 100
+					if name == "low hp" {
+						hp = 10
+					}
+					got, err := capture.Calculate(bulbasaur, hp, 100, opts)
+					if err != nil || got.Probability <= base.Probability {
+						t.Errorf("generation %d, %s: want better than %.4f; got (%.4f, %v)", gen, name, base.Probability, got.Probability, err)
+					}
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"rejects invalid input and unknown balls",
+		func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := capture.Calculate(bulbasaur, 0, 100, nil); !errors.Is(err, pokeapi.ErrInvalidInput) {
+				t.Errorf("want ErrInvalidInput for a fainted pokemon; got %v", err)
+			}
+			if _, err := capture.Calculate(bulbasaur, 100, 100, &capture.Opts{Ball: "net-ball"}); !errors.Is(err, capture.ErrUnknownBall) {
+				t.Errorf("want ErrUnknownBall for a net ball; got %v", err)
+			}
+			if _, err := capture.Calculate(bulbasaur, 100, 100, &capture.Opts{Ball: "net-ball", BallModifier: 3.5}); err != nil {
+				t.Errorf("want no error for a net ball with a modifier; got %v", err)
+			}
+
+			netBall := &pokeapi.Item{
+				NamedIdentifier: pokeapi.NamedIdentifier{Name: "net-ball"},
+				Category:        pokeapi.NamedAPIResource[pokeapi.ItemCategory]{Name: "special-balls"},
+			}
+			if _, err := capture.BallModifier(netBall); !errors.Is(err, capture.ErrUnknownBall) {
+				t.Errorf("want ErrUnknownBall for a special ball; got %v", err)
+			}
+			greatBall := &pokeapi.Item{
+				NamedIdentifier: pokeapi.NamedIdentifier{Name: capture.GreatBall},
+				Category:        pokeapi.NamedAPIResource[pokeapi.ItemCategory]{Name: capture.StandardBallsCategory},
+			}
+			if m, err := capture.BallModifier(greatBall); m != 1.5 || err != nil {
+				t.Errorf("want (1.5, nil) for a great ball; got (%v, %v)", m, err)
+			}
+		},
+	)
+}
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+
+	r, err := capture.Calculate(&pokeapi.PokemonSpecies{CaptureRate: 45}, 100, 100, nil)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	est := capture.Simulate(r, 20000, 1000, rand.New(rand.NewPCG(1, 2)))
+	if want := r.ExpectedThrows(); est.Escaped != 0 || math.Abs(est.MeanThrows-want)/want > 0.05 {
+		t.Errorf("want a mean of about %.2f throws with none escaping; got %+v", want, est)
+	}
+	if est.MedianThrows > est.P90Throws {
+		t.Errorf("want the median to be at most the 90th percentile; got %+v", est)
+	}
+
+	if est := capture.Simulate(capture.Result{Shakes: 4}, 10, 5, nil); est.Escaped != 10 || est.MeanThrows != 0 {
+		t.Errorf("want every trial to escape an uncatchable pokemon; got %+v", est)
+	}
+}
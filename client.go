@@ -142,6 +142,53 @@ func (r APIResource[T]) Get(ctx context.Context, c *Client) (*T, error) {
 	return do[*T](ctx, c, r.URL, nil)
 }
 
+// ID returns the numeric ID of the resource being referred to, parsed from its
+// URL without retrieving it. If the URL cannot be parsed, 0 is returned - no
+// PokéAPI resource has that ID.
+func (r APIResource[T]) ID() int {
+	_, id, err := ParseResourceURL(r.URL)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// ResourceName returns the kebab-case name of the endpoint the resource being
+// referred to belongs to, such as "pokemon-species", parsed from its URL without
+// retrieving it. If the URL cannot be parsed, "" is returned.
+func (r APIResource[T]) ResourceName() string {
+	rn, _, err := ParseResourceURL(r.URL)
+	if err != nil {
+		return ""
+	}
+	return rn
+}
+
+// ParseResourceURL extracts the resource name and numeric ID from the URL of a
+// single resource, such as
+//
+//	https://pokeapi.co/api/v2/pokemon-species/25/ => ("pokemon-species", 25)
+//
+// Only the final two path segments are considered, so URLs under a custom
+// [ClientOpts.PokeAPIRoot] are supported. The trailing slash is optional.
+func ParseResourceURL(rawURL string) (resource string, id int, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %w", ErrMalformedResourceURL, err)
+	}
+
+	segments := strings.Split(trimSlash(u.Path), "/")
+	if len(segments) < 2 || segments[len(segments)-2] == "" {
+		return "", 0, fmt.Errorf("%w: %q has no resource name", ErrMalformedResourceURL, rawURL)
+	}
+
+	id, err = strconv.Atoi(segments[len(segments)-1])
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("%w: %q has no numeric id", ErrMalformedResourceURL, rawURL)
+	}
+	return segments[len(segments)-2], id, nil
+}
+
 // A NamedIdentifier is embedded into resources that are named.
 //
 // A resource directly embedding a NamedIdentifier will have a named get/list
@@ -228,6 +275,53 @@ func (p *Page[R, T]) GetPrevious(ctx context.Context, c *Client) (*Page[R, T], e
 	return doPage[R, T](ctx, c, *p.Previous, nil)
 }
 
+// GetOffset retrieves the Page of the same list that starts at the given
+// offset, using the page size implied by Page.Next or Page.Previous. If the
+// Page has neither link, it contains the entire list and so is not refetched:
+// ErrListExhausted is returned instead.
+func (p *Page[R, T]) GetOffset(ctx context.Context, c *Client, offset int) (*Page[R, T], error) {
+	link := p.Next
+	if link == nil {
+		link = p.Previous
+	}
+	if link == nil {
+		return nil, ErrListExhausted
+	}
+
+	u, err := url.Parse(*link)
+	if err != nil {
+		return nil, fmt.Errorf("parsing page link: %w", err)
+	}
+
+	limit, err := strconv.Atoi(u.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = len(p.Results)
+	}
+
+	u.RawQuery = ""
+	return doPage[R, T](ctx, c, u.String(), &ListOpts{Limit: limit, Offset: offset})
+}
+
+// Offset returns the position of the first of the Page.Results within the
+// whole list. It is derived from Page.Next where present - as Page.Previous may
+// be clamped to the start of the list - and from Page.Count otherwise.
+func (p *Page[R, T]) Offset() int {
+	if p.Next != nil {
+		if u, err := url.Parse(*p.Next); err == nil {
+			q := u.Query()
+			if offset, err := strconv.Atoi(q.Get("offset")); err == nil {
+				limit, err := strconv.Atoi(q.Get("limit"))
+				if err != nil {
+					limit = len(p.Results)
+				}
+				return max(offset-limit, 0)
+			}
+		}
+	}
+
+	return max(p.Count-len(p.Results), 0)
+}
+
 // The noCache is the default Cache implementation used by a Client. While it is
 // valid for use, it does not perform any actual caching.
 type noCache struct{}
@@ -241,25 +335,32 @@ func zero[T any]() (z T) { return }
 
 // do performs a type-safe http GET operation, using the Client's cache &
 // http.Client.
-func do[T any](ctx context.Context, c *Client, url string, values url.Values) (T, error) {
+func do[T any](ctx context.Context, c *Client, rawURL string, values url.Values) (T, error) {
+	if len(values) != 0 {
+		// the query is applied up-front so that the cache sees the full url.
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return zero[T](), fmt.Errorf("parsing url: %w", err)
+		}
+
+		qry := u.Query()
+		for field, val := range values {
+			qry[field] = val
+		}
+		u.RawQuery = qry.Encode()
+		rawURL = u.String()
+	}
+
 	res, err := c.cache.Lookup(
 		ctx,
-		url,
+		rawURL,
 		func(ctx context.Context) (any, error) {
-			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
 			if err != nil {
 				return nil, fmt.Errorf("creating request: %w", err)
 			}
 			req.Header.Set("Accept", "application/json")
 
-			if len(values) != 0 {
-				qry := req.URL.Query()
-				for field, val := range values {
-					qry[field] = val
-				}
-				req.URL.RawQuery = qry.Encode()
-			}
-
 			resp, err := c.client.Do(req)
 			if err != nil {
 				return nil, fmt.Errorf("performing request: %w", err)
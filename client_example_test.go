@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
 
 	"github.com/nightmarlin/pokeapi"
 	"github.com/nightmarlin/pokeapi/cache"
@@ -67,3 +68,28 @@ func ExampleClient() {
 
 	printf("and it goes in the %s pocket", category.Pocket.Name)
 }
+
+func ExampleAPIResource_ID() {
+	var (
+		ctx, cancel = signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+		c           = pokeapi.NewClient(&pokeapi.ClientOpts{Cache: cache.NewLRU(nil)})
+	)
+	defer cancel()
+
+	gen, err := c.GetGeneration(ctx, "generation-iv")
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to get generation-iv: %v", err)
+		return
+	}
+
+	// sort the generation's species by national dex number, without fetching
+	// any of them.
+	slices.SortFunc(
+		gen.PokemonSpecies,
+		func(a, b pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]) int { return a.ID() - b.ID() },
+	)
+
+	for _, s := range gen.PokemonSpecies {
+		fmt.Printf("#%03d %s\n", s.ID(), s.Name)
+	}
+}
@@ -69,6 +69,66 @@ func Test_do(t *testing.T) {
 	)
 }
 
+func Test_do_cacheKeyIncludesQuery(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		ts    = httptest.NewServer(http.HandlerFunc(echoHandler))
+		cache recordingCache
+		c     = NewClient(&ClientOpts{Cache: &cache, PokeAPIRoot: ts.URL, HTTPClient: ts.Client()})
+	)
+	t.Cleanup(ts.Close)
+
+	for _, opts := range []*ListOpts{nil, {Offset: 20, Limit: 20}} {
+		if _, err := do[echoResp](ctx, c, c.listURL(PokemonResource), opts.urlValues()); err != nil {
+			t.Fatalf("want no error; got %v", err)
+		}
+	}
+
+	want := []string{ts.URL + "/pokemon/", ts.URL + "/pokemon/?limit=20&offset=20"}
+	if len(cache.lookups) != len(want) || cache.lookups[0] != want[0] || cache.lookups[1] != want[1] {
+		t.Errorf("want cache lookups %v; got %v", want, cache.lookups)
+	}
+}
+
+func TestParseResourceURL(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		url      string
+		resource string
+		id       int
+		wantErr  bool
+	}{
+		{url: "https://pokeapi.co/api/v2/pokemon-species/25/", resource: "pokemon-species", id: 25},
+		{url: "https://pokeapi.co/api/v2/pokemon-species/25", resource: "pokemon-species", id: 25},
+		{url: "http://localhost:8000/custom/root/api/v2/evolution-chain/10/", resource: "evolution-chain", id: 10},
+		{url: "/machine/1/", resource: "machine", id: 1},
+		{url: "https://pokeapi.co/api/v2/pokemon/pikachu/", wantErr: true},
+		{url: "https://pokeapi.co/api/v2/pokemon/", wantErr: true},
+		{url: "https://pokeapi.co/25/", wantErr: true},
+		{url: "", wantErr: true},
+	} {
+		resource, id, err := ParseResourceURL(tc.url)
+		if tc.wantErr {
+			if !errors.Is(err, ErrMalformedResourceURL) {
+				t.Errorf("%q: want ErrMalformedResourceURL; got (%q, %d, %v)", tc.url, resource, id, err)
+			}
+			continue
+		}
+
+		if resource != tc.resource || id != tc.id || err != nil {
+			t.Errorf("%q: want (%q, %d, nil); got (%q, %d, %v)", tc.url, tc.resource, tc.id, resource, id, err)
+		}
+
+		r := APIResource[PokemonSpecies]{URL: tc.url}
+		if r.ID() != tc.id || r.ResourceName() != tc.resource {
+			t.Errorf("%q: want ID() & ResourceName() to be (%d, %q); got (%d, %q)", tc.url, tc.id, tc.resource, r.ID(), r.ResourceName())
+		}
+	}
+}
+
 // region test helpers
 
 // A recordingCache records the lookups performed on it. it is unsafe for concurrent use.
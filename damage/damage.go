@@ -0,0 +1,394 @@
+// Package damage calculates the range of damage a pokeapi.Move deals, from the
+// stats & types of the attacking and defending pokeapi.Pokemon and the
+// effectiveness given by a typechart.Chart.
+//
+// Calculations use the formula from a chosen generation, including same-type
+// attack bonus (STAB), critical hits, stat stages, multi-hit moves and HP drain
+// & recoil. Situational modifiers - weather, abilities, held items, burns,
+// screens and so on - are not taken into account.
+package damage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/stats"
+	"github.com/nightmarlin/pokeapi/typechart"
+)
+
+// The names of the pokeapi.MoveDamageClass es.
+const (
+	Physical = "physical"
+	Special  = "special"
+	Status   = "status"
+)
+
+// Limits on stat stages.
+const (
+	MinStage = -6
+	MaxStage = 6
+)
+
+// ErrNotDamaging is returned when a pokeapi.Move does not deal damage directly,
+// such as status moves and those with variable power. pokeapi.ErrInvalidInput
+// is returned when a generation, level or stat stage is out of range, or no type
+// chart is given.
+var ErrNotDamaging = errors.New("move does not deal direct damage")
+
+// physicalTypes are the types whose moves were physical before generation-iv,
+// when the damage class of a move was decided by its type.
+var physicalTypes = []string{
+	"normal", "fighting", "flying", "poison", "ground", "rock", "bug", "ghost", "steel",
+}
+
+// Stages are the stat stages of a Combatant, each in [MinStage, MaxStage].
+type Stages struct {
+	Attack         int
+	Defense        int
+	SpecialAttack  int
+	SpecialDefense int
+}
+
+// A Combatant is a Pokémon taking part in a battle.
+type Combatant struct {
+	// The Pokémon. It may be nil if both Stats and Types are given.
+	Pokemon *pokeapi.Pokemon
+	Level   int
+
+	// The in-game stats of the Pokémon. If nil, they are calculated from its base
+	// stats with perfect IVs (or DVs) and no EVs (or stat experience).
+	//
+	// In generation-i, SpecialAttack and SpecialDefense should both hold the
+	// Special stat.
+	Stats *stats.Spread
+
+	Stages Stages
+
+	// The types of the Pokémon. If nil, the types it had in the chosen
+	// generation are used.
+	Types []string
+}
+
+func (c Combatant) spread(gen int) (stats.Spread, error) {
+	if c.Stats != nil {
+		return *c.Stats, nil
+	}
+	if gen == 1 || gen == 2 {
+		dvs := stats.Spread{
+			Attack: stats.MaxDV, Defense: stats.MaxDV, SpecialAttack: stats.MaxDV, SpecialDefense: stats.MaxDV, Speed: stats.MaxDV,
+		}
+		return stats.CalculateGen12(c.Pokemon, c.Level, dvs, stats.Spread{})
+	}
+	ivs := stats.Spread{
+		HP: stats.MaxIV, Attack: stats.MaxIV, Defense: stats.MaxIV,
+		SpecialAttack: stats.MaxIV, SpecialDefense: stats.MaxIV, Speed: stats.MaxIV,
+	}
+	return stats.Calculate(c.Pokemon, c.Level, ivs, stats.Spread{}, nil)
+}
+
+// baseSpeed returns the base speed of the Pokémon. If the Combatant has no
+// Pokemon, it is worked back from Stats.Speed under the same assumptions as
+// spread: the maximum DV and no stat experience.
+func (c Combatant) baseSpeed() int {
+	if c.Pokemon != nil {
+		return stats.Base(c.Pokemon).Speed
+	}
+	return max(0, ((c.Stats.Speed-5)*100+2*c.Level-1)/(2*c.Level)-stats.MaxDV)
+}
+
+func (c Combatant) types(gen int) []string {
+	if c.Types != nil {
+		return c.Types
+	}
+	return typechart.PokemonTypesAt(c.Pokemon, gen)
+}
+
+// Opts configure a damage calculation.
+type Opts struct {
+	// The ID of the Generation to use the formula from - 1 for generation-i and
+	// so on - or pokeapi.LatestGeneration.
+	Generation int
+
+	// The Chart to take type effectiveness from. It is moved to the chosen
+	// generation with typechart.Chart.AtGeneration.
+	Chart *typechart.Chart
+
+	// Calculate damage for a critical hit.
+	Critical bool
+}
+
+// A Result holds the damage a pokeapi.Move deals.
+type Result struct {
+	// The damage dealt by each possible random roll for a single hit, from
+	// lowest to highest.
+	Rolls []int
+
+	// The range of damage dealt by a single hit.
+	Min, Max int
+
+	// The number of times the move hits, and the resulting range of total
+	// damage.
+	MinHits, MaxHits   int
+	TotalMin, TotalMax int
+
+	// The range of total damage as a percentage of the defender's maximum HP.
+	MinPercent, MaxPercent float64
+
+	// The range of HP the attacker restores by draining (if positive) or loses to
+	// recoil (if negative), from the total damage.
+	MinDrain, MaxDrain int
+
+	Effectiveness float64 // The type effectiveness multiplier.
+	STAB          bool    // Whether the same-type attack bonus applied.
+	DamageClass   string  // Physical or Special.
+
+	// The chance of landing a critical hit, in [0, 1].
+	CritChance float64
+}
+
+func stageMultiplier(stage int) (num, den int) {
+	return max(2, 2+stage), max(2, 2-stage)
+}
+
+func applyStage(stat, stage int) int {
+	num, den := stageMultiplier(stage)
+	return stat * num / den
+}
+
+// CritChance returns the chance of landing a critical hit with a move of the
+// given pokeapi.MoveMetaData.CritRate stage, in the given generation. In
+// generation-i, the chance depends on the attacker's base speed instead; high
+// critical hit moves have a CritRate of 1 or more.
+func CritChance(critRate, gen int, attacker *pokeapi.Pokemon) float64 {
+	return critChance(critRate, gen, stats.Base(attacker).Speed)
+}
+
+func critChance(critRate, gen, baseSpeed int) float64 {
+	if gen == 1 {
+		speed := baseSpeed / 2
+		if critRate > 0 {
+			speed *= 8
+		}
+		return float64(min(speed, 255)) / 256
+	}
+
+	var table []float64
+	switch {
+	case gen >= 2 && gen <= 5:
+		table = []float64{1.0 / 16, 1.0 / 8, 1.0 / 4, 1.0 / 3, 1.0 / 2}
+	case gen == 6:
+		table = []float64{1.0 / 16, 1.0 / 8, 1.0 / 2, 1}
+	default:
+		table = []float64{1.0 / 24, 1.0 / 8, 1.0 / 2, 1}
+	}
+	return table[min(max(critRate, 0), len(table)-1)]
+}
+
+// pokeRound multiplies n by the 4096ths num, rounding halves down, as the
+// games do from generation-v onwards.
+func pokeRound(n, num int) int {
+	return (n*num + 2047) / 4096
+}
+
+// Calculate returns the damage the pokeapi.Move deals when used by the attacker
+// against the defender.
+func Calculate(attacker, defender Combatant, move *pokeapi.Move, opts Opts) (Result, error) {
+	gen := opts.Generation
+	if gen < pokeapi.LatestGeneration {
+		return Result{}, fmt.Errorf("%w: generation %d", pokeapi.ErrInvalidInput, gen)
+	}
+	if gen == pokeapi.LatestGeneration {
+		gen = math.MaxInt
+	}
+	if opts.Chart == nil {
+		return Result{}, fmt.Errorf("%w: no type chart", pokeapi.ErrInvalidInput)
+	}
+
+	if move.Power == nil || *move.Power == 0 || move.DamageClass.Name == Status {
+		return Result{}, fmt.Errorf("%w: %q", ErrNotDamaging, move.Name)
+	}
+	for _, c := range []Combatant{attacker, defender} {
+		if c.Pokemon == nil && (c.Stats == nil || c.Types == nil) {
+			return Result{}, fmt.Errorf("%w: no pokemon, stats or types", pokeapi.ErrInvalidInput)
+		}
+	}
+	for _, l := range []int{attacker.Level, defender.Level} {
+		if l < stats.MinLevel || l > stats.MaxLevel {
+			return Result{}, fmt.Errorf("%w: level %d", pokeapi.ErrInvalidInput, l)
+		}
+	}
+	for _, s := range []int{
+		attacker.Stages.Attack, attacker.Stages.SpecialAttack, defender.Stages.Defense, defender.Stages.SpecialDefense,
+	} {
+		if s < MinStage || s > MaxStage {
+			return Result{}, fmt.Errorf("%w: stat stage %d", pokeapi.ErrInvalidInput, s)
+		}
+	}
+
+	chart, err := opts.Chart.AtGeneration(opts.Generation)
+	if err != nil {
+		return Result{}, err
+	}
+
+	aStats, err := attacker.spread(gen)
+	if err != nil {
+		return Result{}, fmt.Errorf("calculating attacker stats: %w", err)
+	}
+	dStats, err := defender.spread(gen)
+	if err != nil {
+		return Result{}, fmt.Errorf("calculating defender stats: %w", err)
+	}
+
+	res := Result{DamageClass: move.DamageClass.Name, MinHits: 1, MaxHits: 1}
+	if gen < 4 {
+		res.DamageClass = Special
+		if slices.Contains(physicalTypes, move.Type.Name) {
+			res.DamageClass = Physical
+		}
+	}
+
+	var (
+		a, d           int
+		aStage, dStage int
+	)
+	if res.DamageClass == Physical {
+		a, d = aStats.Attack, dStats.Defense
+		aStage, dStage = attacker.Stages.Attack, defender.Stages.Defense
+	} else {
+		a, d = aStats.SpecialAttack, dStats.SpecialDefense
+		aStage, dStage = attacker.Stages.SpecialAttack, defender.Stages.SpecialDefense
+	}
+
+	level := attacker.Level
+	switch {
+	case opts.Critical && gen == 1:
+		// critical hits in generation-i double the level, and ignore stat stages.
+		level *= 2
+	case opts.Critical:
+		// critical hits ignore stages that would lower the damage dealt.
+		a, d = applyStage(a, max(aStage, 0)), applyStage(d, min(dStage, 0))
+	default:
+		a, d = applyStage(a, aStage), applyStage(d, dStage)
+	}
+
+	res.Effectiveness, err = chart.Effectiveness(move.Type.Name, defender.types(opts.Generation)...)
+	if err != nil {
+		return Result{}, err
+	}
+	res.STAB = slices.Contains(attacker.types(opts.Generation), move.Type.Name)
+
+	critRate := 0
+	if move.Meta != nil {
+		critRate = move.Meta.CritRate
+		if move.Meta.MinHits != nil && move.Meta.MaxHits != nil {
+			res.MinHits, res.MaxHits = *move.Meta.MinHits, *move.Meta.MaxHits
+		}
+	}
+	res.CritChance = critChance(critRate, gen, attacker.baseSpeed())
+
+	base := (2*level/5 + 2) * *move.Power * a / max(d, 1) / 50
+	if gen <= 2 {
+		base = min(base, 997)
+	}
+	base += 2
+
+	if res.Effectiveness == typechart.NoEffect {
+		res.Rolls = []int{0}
+	} else if gen <= 2 {
+		res.Rolls = rollsGen12(base, gen, opts.Critical, res.STAB, res.Effectiveness)
+	} else {
+		res.Rolls = rolls(base, gen, opts.Critical, res.STAB, res.Effectiveness)
+	}
+
+	res.Min, res.Max = res.Rolls[0], res.Rolls[len(res.Rolls)-1]
+	res.TotalMin, res.TotalMax = res.Min*res.MinHits, res.Max*res.MaxHits
+	if dStats.HP > 0 {
+		res.MinPercent = float64(res.TotalMin) * 100 / float64(dStats.HP)
+		res.MaxPercent = float64(res.TotalMax) * 100 / float64(dStats.HP)
+	}
+
+	if move.Meta != nil && move.Meta.Drain != 0 && res.TotalMax > 0 {
+		drain := func(total int) int {
+			hp := total * move.Meta.Drain / 100
+			if hp == 0 {
+				// at least 1 HP is always drained or lost.
+				hp = move.Meta.Drain / abs(move.Meta.Drain)
+			}
+			return hp
+		}
+		res.MinDrain, res.MaxDrain = drain(res.TotalMin), drain(res.TotalMax)
+	}
+
+	return res, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// applyEffectiveness multiplies the damage by each factor of the type
+// effectiveness in turn, as the games do.
+func applyEffectiveness(dmg int, eff float64) int {
+	for ; eff >= typechart.SuperEffective; eff /= typechart.SuperEffective {
+		dmg *= 2
+	}
+	for ; eff <= typechart.NotVeryEffective; eff *= 2 {
+		dmg /= 2
+	}
+	return dmg
+}
+
+// rolls implements the damage modifiers from generation-iii onwards.
+func rolls(base, gen int, crit, stab bool, eff float64) []int {
+	res := make([]int, 0, 16)
+	for r := 85; r <= 100; r++ {
+		dmg := base
+		if crit {
+			if gen >= 6 {
+				dmg = dmg * 3 / 2
+			} else {
+				dmg *= 2
+			}
+		}
+		dmg = dmg * r / 100
+		if stab {
+			if gen >= 5 {
+				dmg = pokeRound(dmg, 6144)
+			} else {
+				dmg = dmg * 3 / 2
+			}
+		}
+		dmg = applyEffectiveness(dmg, eff)
+		res = append(res, max(dmg, 1))
+	}
+	return res
+}
+
+// rollsGen12 implements the damage modifiers of generations i & ii, which used
+// a random factor out of 255.
+func rollsGen12(base, gen int, crit, stab bool, eff float64) []int {
+	dmg := base
+	if crit && gen == 2 {
+		dmg *= 2
+	}
+	if stab {
+		dmg = dmg * 3 / 2
+	}
+	dmg = applyEffectiveness(dmg, eff)
+
+	if dmg <= 1 {
+		return []int{max(dmg, 1)}
+	}
+
+	res := make([]int, 0, 39)
+	for r := 217; r <= 255; r++ {
+		res = append(res, max(dmg*r/255, 1))
+	}
+	return res
+}
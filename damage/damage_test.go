@@ -0,0 +1,283 @@
+package damage_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/damage"
+	"github.com/nightmarlin/pokeapi/stats"
+	"github.com/nightmarlin/pokeapi/typechart"
+	"github.com/nightmarlin/pokeapi/typechart/typecharttest"
+)
+
+func move(name, typ, class string, power int) *pokeapi.Move {
+	return &pokeapi.Move{
+		NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+		Power:           &power,
+		Type:            pokeapi.NamedAPIResource[pokeapi.Type]{Name: typ},
+		DamageClass:     pokeapi.NamedAPIResource[pokeapi.MoveDamageClass]{Name: class},
+		Meta:            &pokeapi.MoveMetaData{},
+	}
+}
+
+// combatant is a level 50 Pokémon with 150 HP, and 100 in every other stat.
+func combatant(types ...string) damage.Combatant {
+	return damage.Combatant{
+		Pokemon: &pokeapi.Pokemon{},
+		Level:   50,
+		Stats:   &stats.Spread{HP: 150, Attack: 100, Defense: 100, SpecialAttack: 100, SpecialDefense: 100, Speed: 100},
+		Types:   types,
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	t.Parallel()
+
+	surf := move("surf", "water", damage.Special, 80)
+
+	for _, tc := range []struct {
+		name               string
+		attacker, defender damage.Combatant
+		move               *pokeapi.Move
+		opts               damage.Opts
+		min, max           int
+		rolls              int
+	}{
+		{
+			name:     "stab",
+			attacker: combatant("water"), defender: combatant("normal"),
+			move: surf, min: 46, max: 55, rolls: 16,
+		},
+		{
+			name:     "super effective",
+			attacker: combatant("water"), defender: combatant("fire"),
+			move: surf, min: 92, max: 110, rolls: 16,
+		},
+		{
+			name:     "not very effective without stab",
+			attacker: combatant("normal"), defender: combatant("grass"),
+			move: surf, min: 15, max: 18, rolls: 16,
+		},
+		{
+			name:     "immune",
+			attacker: combatant("normal"), defender: combatant("ghost"),
+			move: move("tackle", "normal", damage.Physical, 80), min: 0, max: 0, rolls: 1,
+		},
+		{
+			name:     "critical hit",
+			attacker: combatant("water"), defender: combatant("normal"),
+			move: surf, opts: damage.Opts{Critical: true}, min: 69, max: 82, rolls: 16,
+		},
+		{
+			name:     "generation-iv critical hit",
+			attacker: combatant("water"), defender: combatant("normal"),
+			move: surf, opts: damage.Opts{Generation: 4, Critical: true}, min: 93, max: 111, rolls: 16,
+		},
+		{
+			name: "stat stages",
+			attacker: func() damage.Combatant {
+				c := combatant("water")
+				c.Stages.SpecialAttack = 2
+				return c
+			}(),
+			defender: combatant("normal"),
+			move:     surf, min: 91, max: 108, rolls: 16,
+		},
+		{
+			name:     "critical hits ignore a raised defense",
+			attacker: combatant("water"),
+			defender: func() damage.Combatant {
+				c := combatant("normal")
+				c.Stages.SpecialDefense = 6
+				return c
+			}(),
+			move: surf, opts: damage.Opts{Critical: true}, min: 69, max: 82, rolls: 16,
+		},
+		{
+			name:     "generation-i",
+			attacker: combatant("water"), defender: combatant("normal"),
+			move: surf, opts: damage.Opts{Generation: 1}, min: 46, max: 55, rolls: 39,
+		},
+	} {
+		opts := tc.opts
+		opts.Chart = typecharttest.Chart()
+
+		res, err := damage.Calculate(tc.attacker, tc.defender, tc.move, opts)
+		if err != nil {
+			t.Errorf("%s: want no error; got %v", tc.name, err)
+			continue
+		}
+		if res.Min != tc.min || res.Max != tc.max || len(res.Rolls) != tc.rolls {
+			t.Errorf(
+				"%s: want %d-%d over %d rolls; got %d-%d over %d rolls",
+				tc.name, tc.min, tc.max, tc.rolls, res.Min, res.Max, len(res.Rolls),
+			)
+		}
+	}
+}
+
+func TestCalculate_Result(t *testing.T) {
+	t.Parallel()
+
+	t.Run(
+		"reports the percentage of hp",
+		func(t *testing.T) {
+			t.Parallel()
+
+			res, err := damage.Calculate(
+				combatant("water"), combatant("fire"), move("surf", "water", damage.Special, 80), damage.Opts{Chart: typecharttest.Chart()},
+			)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if math.Abs(res.MinPercent-61.33) > 0.01 || math.Abs(res.MaxPercent-73.33) > 0.01 {
+				t.Errorf("want 61.33%%-73.33%%; got %.2f%%-%.2f%%", res.MinPercent, res.MaxPercent)
+			}
+			if !res.STAB || res.Effectiveness != typechart.SuperEffective {
+				t.Errorf("want stab & super effective; got %+v", res)
+			}
+		},
+	)
+
+	t.Run(
+		"multiplies multi-hit moves",
+		func(t *testing.T) {
+			t.Parallel()
+
+			m := move("water-shuriken", "water", damage.Special, 80)
+			minHits, maxHits := 2, 5
+			m.Meta.MinHits, m.Meta.MaxHits = &minHits, &maxHits
+
+			res, err := damage.Calculate(combatant("water"), combatant("normal"), m, damage.Opts{Chart: typecharttest.Chart()})
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if res.TotalMin != 92 || res.TotalMax != 275 {
+				t.Errorf("want 92-275 in total; got %d-%d", res.TotalMin, res.TotalMax)
+			}
+		},
+	)
+
+	t.Run(
+		"drains and recoils",
+		func(t *testing.T) {
+			t.Parallel()
+
+			drain := move("giga-drain", "water", damage.Special, 80)
+			drain.Meta.Drain = 50
+			res, err := damage.Calculate(combatant("water"), combatant("normal"), drain, damage.Opts{Chart: typecharttest.Chart()})
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if res.MinDrain != 23 || res.MaxDrain != 27 {
+				t.Errorf("want 23-27 drained; got %d-%d", res.MinDrain, res.MaxDrain)
+			}
+
+			recoil := move("wave-crash", "water", damage.Physical, 80)
+			recoil.Meta.Drain = -33
+			res, err = damage.Calculate(combatant("water"), combatant("normal"), recoil, damage.Opts{Chart: typecharttest.Chart()})
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if res.MinDrain != -15 || res.MaxDrain != -18 {
+				t.Errorf("want -15 to -18 recoil; got %d to %d", res.MinDrain, res.MaxDrain)
+			}
+		},
+	)
+
+	t.Run(
+		"decides the damage class by type before generation-iv",
+		func(t *testing.T) {
+			t.Parallel()
+
+			attacker := combatant("water")
+			attacker.Stats.Attack = 200
+
+			res, err := damage.Calculate(
+				attacker, combatant("normal"), move("waterfall", "water", damage.Physical, 80), damage.Opts{Generation: 3, Chart: typecharttest.Chart()},
+			)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if res.DamageClass != damage.Special || res.Max != 55 {
+				t.Errorf("want a special move dealing at most 55; got %s dealing at most %d", res.DamageClass, res.Max)
+			}
+		},
+	)
+}
+
+func TestCalculate_Errors(t *testing.T) {
+	t.Parallel()
+
+	opts := damage.Opts{Chart: typecharttest.Chart()}
+
+	growl := move("growl", "normal", damage.Status, 0)
+	if _, err := damage.Calculate(combatant("normal"), combatant("normal"), growl, opts); !errors.Is(err, damage.ErrNotDamaging) {
+		t.Errorf("want ErrNotDamaging for a status move; got %v", err)
+	}
+
+	attacker := combatant("water")
+	attacker.Stages.SpecialAttack = 7
+	surf := move("surf", "water", damage.Special, 80)
+	if _, err := damage.Calculate(attacker, combatant("normal"), surf, opts); !errors.Is(err, pokeapi.ErrInvalidInput) {
+		t.Errorf("want ErrInvalidInput for stage +7; got %v", err)
+	}
+}
+
+func TestCritChance(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		critRate, gen int
+		want          float64
+	}{
+		{critRate: 0, gen: 2, want: 1.0 / 16},
+		{critRate: 1, gen: 4, want: 1.0 / 8},
+		{critRate: 2, gen: 6, want: 1.0 / 2},
+		{critRate: 0, gen: pokeapi.LatestGeneration, want: 1.0 / 24},
+		{critRate: 3, gen: pokeapi.LatestGeneration, want: 1},
+	} {
+		if got := damage.CritChance(tc.critRate, tc.gen, &pokeapi.Pokemon{}); got != tc.want {
+			t.Errorf("crit rate %d in generation %d: want %v; got %v", tc.critRate, tc.gen, tc.want, got)
+		}
+	}
+
+	persian := &pokeapi.Pokemon{
+		Stats: []pokeapi.PokemonStat{{Stat: pokeapi.NamedAPIResource[pokeapi.Stat]{Name: stats.Speed}, BaseStat: 115}},
+	}
+	if got := damage.CritChance(0, 1, persian); got != 57.0/256 {
+		t.Errorf("want 57/256 for persian in generation-i; got %v", got)
+	}
+	if got := damage.CritChance(1, 1, persian); got != 255.0/256 {
+		t.Errorf("want 255/256 for persian's slash in generation-i; got %v", got)
+	}
+}
+
+func TestCalculate_withoutPokemon(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// a level 50 persian, with base speed 115.
+		persian = damage.Combatant{
+			Level: 50,
+			Stats: &stats.Spread{HP: 140, Attack: 100, Defense: 85, SpecialAttack: 95, SpecialDefense: 95, Speed: 135},
+			Types: []string{"normal"},
+		}
+		opts = damage.Opts{Generation: 1, Chart: typecharttest.Chart()}
+	)
+
+	res, err := damage.Calculate(persian, combatant("normal"), move("tackle", "normal", damage.Physical, 35), opts)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	if res.CritChance != 57.0/256 {
+		t.Errorf("want a crit chance of 57/256 from persian's base speed; got %v", res.CritChance)
+	}
+
+	persian.Types = nil
+	if _, err := damage.Calculate(persian, combatant("normal"), move("tackle", "normal", damage.Physical, 35), opts); !errors.Is(err, pokeapi.ErrInvalidInput) {
+		t.Errorf("want ErrInvalidInput without a pokemon or types; got %v", err)
+	}
+}
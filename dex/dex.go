@@ -0,0 +1,126 @@
+// Package dex converts between the national and regional numbers of
+// pokeapi.PokemonSpecies, and tracks progress towards completing each
+// pokeapi.Pokedex.
+//
+// A species' national number is its pokeapi.PokemonSpecies ID, so national
+// numbers never require the national pokeapi.Pokedex to be retrieved.
+package dex
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// National is the name of the pokeapi.Pokedex numbering every species.
+const National = "national"
+
+// NumberOf returns the species' number in the named pokeapi.Pokedex, using its
+// pokeapi.PokemonSpecies.PokedexNumbers.
+func NumberOf(s *pokeapi.PokemonSpecies, dex string) (int, bool) {
+	if dex == National {
+		return s.ID, s.ID != 0
+	}
+	for _, n := range s.PokedexNumbers {
+		if n.Pokedex.Name == dex {
+			return n.EntryNumber, true
+		}
+	}
+	return 0, false
+}
+
+// numbering is the entries of a single pokeapi.Pokedex.
+type numbering struct {
+	bySpecies  map[string]int
+	byNumber   map[int]pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]
+	byNational map[int]int // national number => regional number.
+}
+
+// Numbers maps between the national numbers of species and their numbers in
+// each of its pokeapi.Pokedex es. It is safe for concurrent use.
+type Numbers struct {
+	dexes map[string]numbering
+}
+
+// NewNumbers builds Numbers from the entries of the provided pokedexes.
+func NewNumbers(dexes ...*pokeapi.Pokedex) *Numbers {
+	n := Numbers{dexes: make(map[string]numbering, len(dexes))}
+	for _, d := range dexes {
+		nb := numbering{
+			bySpecies:  make(map[string]int, len(d.PokemonEntries)),
+			byNumber:   make(map[int]pokeapi.NamedAPIResource[pokeapi.PokemonSpecies], len(d.PokemonEntries)),
+			byNational: make(map[int]int, len(d.PokemonEntries)),
+		}
+		for _, e := range d.PokemonEntries {
+			nb.bySpecies[e.PokemonSpecies.Name] = e.EntryNumber
+			nb.byNumber[e.EntryNumber] = e.PokemonSpecies
+			nb.byNational[e.PokemonSpecies.ID()] = e.EntryNumber
+		}
+		n.dexes[d.Name] = nb
+	}
+	return &n
+}
+
+// BuildNumbers retrieves the named pokedexes using the pokeapi.Client, and
+// builds Numbers from them.
+func BuildNumbers(ctx context.Context, c *pokeapi.Client, dexes ...string) (*Numbers, error) {
+	res := make([]*pokeapi.Pokedex, len(dexes))
+	for i, name := range dexes {
+		d, err := c.GetPokedex(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("getting pokedex %q: %w", name, err)
+		}
+		res[i] = d
+	}
+	return NewNumbers(res...), nil
+}
+
+// Number returns the named species' number in the named pokeapi.Pokedex.
+func (n *Numbers) Number(dex, species string) (int, bool) {
+	num, ok := n.dexes[dex].bySpecies[species]
+	return num, ok
+}
+
+// Species returns the species with the number in the named pokeapi.Pokedex.
+func (n *Numbers) Species(dex string, number int) (pokeapi.NamedAPIResource[pokeapi.PokemonSpecies], bool) {
+	s, ok := n.dexes[dex].byNumber[number]
+	return s, ok
+}
+
+// ToRegional returns the number in the named pokeapi.Pokedex of the species
+// with the national number, if it is in that pokedex.
+//
+//	n.ToRegional(25, "original-johto") // 22, true: pikachu
+func (n *Numbers) ToRegional(national int, dex string) (int, bool) {
+	if dex == National {
+		return national, national > 0
+	}
+	num, ok := n.dexes[dex].byNational[national]
+	return num, ok
+}
+
+// ToNational returns the national number of the species with the number in the
+// named pokeapi.Pokedex.
+//
+//	n.ToNational("original-johto", 22) // 25, true: pikachu
+func (n *Numbers) ToNational(dex string, regional int) (int, bool) {
+	if dex == National {
+		return regional, regional > 0
+	}
+	s, ok := n.dexes[dex].byNumber[regional]
+	if !ok || s.ID() == 0 {
+		return 0, false
+	}
+	return s.ID(), true
+}
+
+// Convert returns the number in the pokedex named to of the species with the
+// number in the pokedex named from.
+func (n *Numbers) Convert(from string, number int, to string) (int, bool) {
+	national, ok := n.ToNational(from, number)
+	if !ok {
+		return 0, false
+	}
+	return n.ToRegional(national, to)
+}
@@ -0,0 +1,120 @@
+package dex_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/dex"
+)
+
+func speciesRef(name string, id int) pokeapi.NamedAPIResource[pokeapi.PokemonSpecies] {
+	return pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+		APIResource: pokeapi.APIResource[pokeapi.PokemonSpecies]{
+			URL: "https://pokeapi.co/api/v2/pokemon-species/" + strconv.Itoa(id) + "/",
+		},
+		Name: name,
+	}
+}
+
+func pokedex(name string, species ...pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]) *pokeapi.Pokedex {
+	d := pokeapi.Pokedex{NamedIdentifier: pokeapi.NamedIdentifier{Name: name}}
+	for i, s := range species {
+		d.PokemonEntries = append(d.PokemonEntries, pokeapi.PokemonEntry{EntryNumber: i + 1, PokemonSpecies: s})
+	}
+	return &d
+}
+
+func TestNumberOf(t *testing.T) {
+	t.Parallel()
+
+	s := &pokeapi.PokemonSpecies{
+		NamedIdentifier: pokeapi.NamedIdentifier{Identifier: pokeapi.Identifier{ID: 25}, Name: "pikachu"},
+		PokedexNumbers: []pokeapi.PokemonSpeciesDexEntry{
+			{EntryNumber: 25, Pokedex: pokeapi.NamedAPIResource[pokeapi.Pokedex]{Name: "kanto"}},
+			{EntryNumber: 22, Pokedex: pokeapi.NamedAPIResource[pokeapi.Pokedex]{Name: "original-johto"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		dex    string
+		want   int
+		wantOK bool
+	}{
+		{dex: dex.National, want: 25, wantOK: true},
+		{dex: "original-johto", want: 22, wantOK: true},
+		{dex: "hoenn", want: 0, wantOK: false},
+	} {
+		if got, ok := dex.NumberOf(s, tc.dex); got != tc.want || ok != tc.wantOK {
+			t.Errorf("%s: want (%d, %t); got (%d, %t)", tc.dex, tc.want, tc.wantOK, got, ok)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chikorita = speciesRef("chikorita", 152)
+		pidgey    = speciesRef("pidgey", 16)
+		pikachu   = speciesRef("pikachu", 25)
+
+		n = dex.NewNumbers(
+			pokedex("kanto", speciesRef("bulbasaur", 1), pidgey, pikachu),
+			pokedex("original-johto", chikorita, pidgey, pikachu),
+		)
+	)
+
+	if got, ok := n.Number("original-johto", "pikachu"); got != 3 || !ok {
+		t.Errorf("want (3, true); got (%d, %t)", got, ok)
+	}
+	if got, ok := n.Species("kanto", 2); got.Name != "pidgey" || !ok {
+		t.Errorf("want (pidgey, true); got (%+v, %t)", got, ok)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		got    func() (int, bool)
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "national to regional",
+			got:    func() (int, bool) { return n.ToRegional(152, "original-johto") },
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name:   "national to regional, not in dex",
+			got:    func() (int, bool) { return n.ToRegional(152, "kanto") },
+			wantOK: false,
+		},
+		{
+			name:   "regional to national",
+			got:    func() (int, bool) { return n.ToNational("original-johto", 3) },
+			want:   25,
+			wantOK: true,
+		},
+		{
+			name:   "regional to national, unknown dex",
+			got:    func() (int, bool) { return n.ToNational("hoenn", 1) },
+			wantOK: false,
+		},
+		{
+			name:   "regional to regional",
+			got:    func() (int, bool) { return n.Convert("kanto", 2, "original-johto") },
+			want:   2,
+			wantOK: true,
+		},
+		{
+			name:   "national to national",
+			got:    func() (int, bool) { return n.Convert(dex.National, 16, dex.National) },
+			want:   16,
+			wantOK: true,
+		},
+	} {
+		if got, ok := tc.got(); got != tc.want || ok != tc.wantOK {
+			t.Errorf("%s: want (%d, %t); got (%d, %t)", tc.name, tc.want, tc.wantOK, got, ok)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/encounters"
+	"golang.org/x/sync/errgroup"
+)
+
+// Progress is how much of a pokeapi.Pokedex, or the pokedexes of a
+// pokeapi.VersionGroup, has been caught.
+type Progress struct {
+	Caught  int
+	Total   int
+	Missing []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies] // In dex order.
+}
+
+// Complete reports whether every species has been caught.
+func (p Progress) Complete() bool { return p.Caught == p.Total }
+
+// Percent returns the percentage of species caught, in [0, 100].
+func (p Progress) Percent() float64 {
+	if p.Total == 0 {
+		return 100
+	}
+	return 100 * float64(p.Caught) / float64(p.Total)
+}
+
+// VersionGroupProgress is the Progress of each pokeapi.Pokedex used by a
+// pokeapi.VersionGroup, and of all of them together - counting each species
+// once.
+type VersionGroupProgress struct {
+	Progress
+
+	Pokedexes map[string]Progress // pokedex name => Progress.
+}
+
+// A Tracker holds the names of the species that have been caught. The zero
+// value is an empty Tracker ready for use. It is safe for concurrent use.
+type Tracker struct {
+	mux    sync.RWMutex
+	caught map[string]bool
+}
+
+// NewTracker returns a Tracker holding the named species.
+func NewTracker(caught ...string) *Tracker {
+	var t Tracker
+	t.Catch(caught...)
+	return &t
+}
+
+// Catch records the named species as caught.
+func (t *Tracker) Catch(species ...string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.caught == nil {
+		t.caught = make(map[string]bool, len(species))
+	}
+	for _, s := range species {
+		t.caught[s] = true
+	}
+}
+
+// Caught reports whether the named species has been caught.
+func (t *Tracker) Caught(species string) bool {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return t.caught[species]
+}
+
+func (t *Tracker) progress(entries []pokeapi.PokemonEntry) Progress {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, func(a, b pokeapi.PokemonEntry) int { return a.EntryNumber - b.EntryNumber })
+
+	p := Progress{Total: len(sorted)}
+	for _, e := range sorted {
+		if t.caught[e.PokemonSpecies.Name] {
+			p.Caught++
+		} else {
+			p.Missing = append(p.Missing, e.PokemonSpecies)
+		}
+	}
+	return p
+}
+
+// Pokedex returns the Progress towards completing the pokeapi.Pokedex.
+func (t *Tracker) Pokedex(d *pokeapi.Pokedex) Progress {
+	return t.progress(d.PokemonEntries)
+}
+
+// VersionGroup retrieves every pokeapi.Pokedex used by the pokeapi.VersionGroup
+// using the pokeapi.Client, and returns the Progress towards completing them.
+func (t *Tracker) VersionGroup(
+	ctx context.Context,
+	c *pokeapi.Client,
+	vg *pokeapi.VersionGroup,
+) (VersionGroupProgress, error) {
+	dexes, err := pokeapi.GetAll(ctx, c, vg.Pokedexes, pokeapi.DefaultConcurrency)
+	if err != nil {
+		return VersionGroupProgress{}, fmt.Errorf("getting pokedexes of %q: %w", vg.Name, err)
+	}
+
+	var (
+		res     = VersionGroupProgress{Pokedexes: make(map[string]Progress, len(dexes))}
+		seen    = make(map[string]bool)
+		entries []pokeapi.PokemonEntry
+	)
+	for _, d := range dexes {
+		res.Pokedexes[d.Name] = t.Pokedex(d)
+		for _, e := range d.PokemonEntries {
+			if !seen[e.PokemonSpecies.Name] {
+				seen[e.PokemonSpecies.Name] = true
+				// order the combined dex by pokedex, then entry number.
+				entries = append(entries, pokeapi.PokemonEntry{EntryNumber: len(entries), PokemonSpecies: e.PokemonSpecies})
+			}
+		}
+	}
+	res.Progress = t.progress(entries)
+	return res, nil
+}
+
+// CatchableOpts configure Catchable.
+type CatchableOpts struct {
+	// The number of species to retrieve the encounters of at once. Default
+	// pokeapi.DefaultConcurrency.
+	Concurrency int
+}
+
+// Catchable retrieves the encounters of every variety of each species using the
+// pokeapi.Client, and returns the encounters.Row s in which each can be caught
+// in a pokeapi.Version of the pokeapi.VersionGroup, keyed by species name.
+// Species that cannot be caught in the wild are omitted. A nil CatchableOpts
+// uses the defaults.
+//
+// The first error that occurs is returned, and cancels any retrievals still in
+// progress.
+//
+//	p, err := t.VersionGroup(ctx, c, vg)
+//	catchable, err := dex.Catchable(ctx, c, vg, p.Missing, nil)
+func Catchable(
+	ctx context.Context,
+	c *pokeapi.Client,
+	vg *pokeapi.VersionGroup,
+	species []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies],
+	opts *CatchableOpts,
+) (map[string][]encounters.Row, error) {
+	concurrency := pokeapi.DefaultConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	var (
+		mux     sync.Mutex
+		res     = make(map[string][]encounters.Row)
+		g, gCtx = errgroup.WithContext(ctx)
+	)
+	g.SetLimit(concurrency)
+
+	for _, ref := range species {
+		g.Go(
+			func() error {
+				rows, err := catchable(gCtx, c, vg, ref)
+				if err != nil {
+					return err
+				}
+				if len(rows) != 0 {
+					mux.Lock()
+					res[ref.Name] = rows
+					mux.Unlock()
+				}
+				return nil
+			},
+		)
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func catchable(
+	ctx context.Context,
+	c *pokeapi.Client,
+	vg *pokeapi.VersionGroup,
+	ref pokeapi.NamedAPIResource[pokeapi.PokemonSpecies],
+) ([]encounters.Row, error) {
+	s, err := ref.Get(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("getting species %q: %w", ref.Name, err)
+	}
+
+	var t encounters.Table
+	for _, v := range s.Varieties {
+		es, err := c.GetPokemonEncounters(ctx, v.Pokemon.Name)
+		if err != nil {
+			return nil, fmt.Errorf("getting encounters of %q: %w", v.Pokemon.Name, err)
+		}
+		t.AddPokemonEncounters(v.Pokemon, es)
+	}
+
+	var res []encounters.Row
+	for _, version := range vg.Versions {
+		res = append(res, t.Query(encounters.Query{Version: version.Name})...)
+	}
+	return res, nil
+}
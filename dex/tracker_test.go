@@ -0,0 +1,191 @@
+package dex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/dex"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+func TestTracker_Pokedex(t *testing.T) {
+	t.Parallel()
+
+	tr := dex.NewTracker("bulbasaur", "pikachu")
+	p := tr.Pokedex(pokedex("kanto", speciesRef("bulbasaur", 1), speciesRef("pidgey", 16), speciesRef("pikachu", 25)))
+
+	if p.Caught != 2 || p.Total != 3 || p.Complete() {
+		t.Errorf("want 2 of 3 caught; got %d of %d", p.Caught, p.Total)
+	}
+	if len(p.Missing) != 1 || p.Missing[0].Name != "pidgey" {
+		t.Errorf("want [pidgey] missing; got %+v", p.Missing)
+	}
+
+	tr.Catch("pidgey")
+	if p := tr.Pokedex(pokedex("kanto", speciesRef("pidgey", 16))); !p.Complete() || p.Percent() != 100 {
+		t.Errorf("want a complete dex; got %+v", p)
+	}
+}
+
+func TestTracker_VersionGroup(t *testing.T) {
+	t.Parallel()
+
+	ts, c := pokeapitest.NewServer(t)
+
+	ref := func(name string, id int) pokeapi.NamedAPIResource[pokeapi.PokemonSpecies] {
+		s := speciesRef(name, id)
+		s.URL = ts.URL + "/pokemon-species/" + name + "/"
+		return s
+	}
+	pokemon := func(name string) pokeapi.PokemonSpeciesVariety {
+		return pokeapi.PokemonSpeciesVariety{
+			IsDefault: true,
+			Pokemon: pokeapi.NamedAPIResource[pokeapi.Pokemon]{
+				APIResource: pokeapi.APIResource[pokeapi.Pokemon]{URL: ts.URL + "/pokemon/" + name + "/"},
+				Name:        name,
+			},
+		}
+	}
+	encounter := func(area, version string) pokeapi.PokemonLocationArea {
+		return pokeapi.PokemonLocationArea{
+			LocationArea: pokeapi.NamedAPIResource[pokeapi.LocationArea]{Name: area},
+			VersionDetails: []pokeapi.VersionEncounterDetail{
+				{
+					Version: pokeapi.NamedAPIResource[pokeapi.Version]{Name: version},
+					EncounterDetails: []pokeapi.Encounter{
+						{
+							MinLevel: 2,
+							MaxLevel: 4,
+							Chance:   30,
+							Method:   pokeapi.NamedAPIResource[pokeapi.EncounterMethod]{Name: "walk"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	ts.Add("/pokedex/kanto/", pokedex("kanto", ref("bulbasaur", 1), ref("pidgey", 16), ref("pikachu", 25)))
+	ts.Add("/pokedex/updated-kanto/", pokedex("updated-kanto", ref("pikachu", 25), ref("mew", 151)))
+	ts.Add(
+		"/pokemon-species/pidgey/",
+		pokeapi.PokemonSpecies{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: "pidgey"},
+			Varieties:       []pokeapi.PokemonSpeciesVariety{pokemon("pidgey")},
+		},
+	)
+	ts.Add(
+		"/pokemon-species/mew/",
+		pokeapi.PokemonSpecies{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: "mew"},
+			Varieties:       []pokeapi.PokemonSpeciesVariety{pokemon("mew")},
+		},
+	)
+	ts.Add(
+		"/pokemon/pidgey/encounters",
+		[]pokeapi.PokemonLocationArea{
+			encounter("kanto-route-1-area", "red"),
+			encounter("kanto-route-2-south-towards-viridian-city", "gold"),
+		},
+	)
+	ts.Add("/pokemon/mew/encounters", []pokeapi.PokemonLocationArea{})
+
+	var (
+		ctx = context.Background()
+		vg  = &pokeapi.VersionGroup{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: "red-blue"},
+			Pokedexes: []pokeapi.NamedAPIResource[pokeapi.Pokedex]{
+				{APIResource: pokeapi.APIResource[pokeapi.Pokedex]{URL: ts.URL + "/pokedex/kanto/"}, Name: "kanto"},
+				{APIResource: pokeapi.APIResource[pokeapi.Pokedex]{URL: ts.URL + "/pokedex/updated-kanto/"}, Name: "updated-kanto"},
+			},
+			Versions: []pokeapi.NamedAPIResource[pokeapi.Version]{{Name: "red"}, {Name: "blue"}},
+		}
+		tr = dex.NewTracker("bulbasaur", "pikachu")
+	)
+
+	p, err := tr.VersionGroup(ctx, c, vg)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	if p.Caught != 2 || p.Total != 4 {
+		t.Errorf("want 2 of 4 caught across both dexes; got %d of %d", p.Caught, p.Total)
+	}
+	if len(p.Missing) != 2 || p.Missing[0].Name != "pidgey" || p.Missing[1].Name != "mew" {
+		t.Errorf("want [pidgey mew] missing; got %+v", p.Missing)
+	}
+	if k := p.Pokedexes["kanto"]; k.Caught != 2 || k.Total != 3 {
+		t.Errorf("want 2 of 3 caught in kanto; got %d of %d", k.Caught, k.Total)
+	}
+	if uk := p.Pokedexes["updated-kanto"]; uk.Caught != 1 || uk.Total != 2 {
+		t.Errorf("want 1 of 2 caught in updated-kanto; got %d of %d", uk.Caught, uk.Total)
+	}
+
+	catchable, err := dex.Catchable(ctx, c, vg, p.Missing, nil)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	if len(catchable) != 1 {
+		t.Errorf("want only pidgey to be catchable; got %+v", catchable)
+	}
+	if rows := catchable["pidgey"]; len(rows) != 1 || rows[0].Area.Name != "kanto-route-1-area" {
+		t.Errorf("want pidgey catchable only in kanto-route-1-area; got %+v", rows)
+	}
+}
+
+func TestCatchable_cancelsOnError(t *testing.T) {
+	t.Parallel()
+
+	stop := make(chan struct{})
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/pokemon-species/slowpoke/" {
+					// wait for the request to be cancelled, or the test to end.
+					select {
+					case <-r.Context().Done():
+					case <-stop:
+					}
+				}
+				http.NotFound(w, r)
+			},
+		),
+	)
+	t.Cleanup(ts.Close)
+	t.Cleanup(func() { close(stop) })
+
+	var (
+		c   = pokeapi.NewClient(&pokeapi.ClientOpts{HTTPClient: ts.Client(), PokeAPIRoot: ts.URL})
+		ref = func(name string) pokeapi.NamedAPIResource[pokeapi.PokemonSpecies] {
+			return pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+				APIResource: pokeapi.APIResource[pokeapi.PokemonSpecies]{URL: ts.URL + "/pokemon-species/" + name + "/"},
+				Name:        name,
+			}
+		}
+		errs = make(chan error, 1)
+	)
+
+	go func() {
+		_, err := dex.Catchable(
+			context.Background(), c, &pokeapi.VersionGroup{},
+			[]pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{ref("slowpoke"), ref("missingno")},
+			&dex.CatchableOpts{Concurrency: 2},
+		)
+		errs <- err
+	}()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, pokeapi.ErrNotFound) {
+			t.Errorf("want ErrNotFound for missingno; got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("want Catchable to cancel slowpoke's retrieval once missingno failed; still waiting")
+	}
+}
@@ -0,0 +1,285 @@
+// Package encounters flattens the nested encounter data of
+// pokeapi.LocationArea s and pokeapi.Pokemon into a Table, which can be queried
+// by Pokémon, area, version, method and the conditions in effect.
+//
+// Each Row of a Table aggregates every slot a Pokémon occupies for the same
+// area, version, method and conditions - so Row.Chance is the total chance of
+// encountering it, and Row.MinLevel & Row.MaxLevel span every slot.
+package encounters
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// A Row is a Pokémon that can be encountered in an area, in a version, using a
+// method, while some conditions are in effect.
+type Row struct {
+	Pokemon pokeapi.NamedAPIResource[pokeapi.Pokemon]         `json:"pokemon"`
+	Area    pokeapi.NamedAPIResource[pokeapi.LocationArea]    `json:"area"`
+	Version pokeapi.NamedAPIResource[pokeapi.Version]         `json:"version"`
+	Method  pokeapi.NamedAPIResource[pokeapi.EncounterMethod] `json:"method"`
+
+	// The pokeapi.EncounterConditionValue s that must be in effect, ordered by
+	// name. Empty if the encounter is always possible.
+	Conditions []pokeapi.NamedAPIResource[pokeapi.EncounterConditionValue] `json:"conditions"`
+
+	MinLevel int `json:"min_level"`
+	MaxLevel int `json:"max_level"`
+	Chance   int `json:"chance"` // The % chance of the encounter, summed across every slot.
+}
+
+// ConditionNames returns the names of the Row's Conditions.
+func (r Row) ConditionNames() []string {
+	res := make([]string, len(r.Conditions))
+	for i, c := range r.Conditions {
+		res[i] = c.Name
+	}
+	return res
+}
+
+// rowKey identifies the Row a slot is aggregated into.
+type rowKey struct {
+	pokemon, area, version, method, conditions string
+}
+
+func (r Row) key() rowKey {
+	return rowKey{
+		pokemon:    r.Pokemon.Name,
+		area:       r.Area.Name,
+		version:    r.Version.Name,
+		method:     r.Method.Name,
+		conditions: strings.Join(r.ConditionNames(), ","),
+	}
+}
+
+func compareRows(a, b Row) int {
+	return cmp.Or(
+		cmp.Compare(a.Version.Name, b.Version.Name),
+		cmp.Compare(a.Area.Name, b.Area.Name),
+		cmp.Compare(a.Method.Name, b.Method.Name),
+		cmp.Compare(a.Pokemon.Name, b.Pokemon.Name),
+		slices.Compare(a.ConditionNames(), b.ConditionNames()),
+	)
+}
+
+// A Table holds aggregated encounter Row s. The zero value is an empty Table
+// ready for use.
+type Table struct {
+	rows  []Row
+	index map[rowKey]int
+
+	// groups maps each pokeapi.EncounterConditionValue name to the name of its
+	// pokeapi.EncounterCondition, once resolved.
+	groups map[string]string
+}
+
+// Rows returns every Row in the Table, ordered by version, area, method, Pokémon
+// then conditions.
+func (t *Table) Rows() []Row {
+	res := slices.Clone(t.rows)
+	slices.SortFunc(res, compareRows)
+	return res
+}
+
+func (t *Table) add(
+	p pokeapi.NamedAPIResource[pokeapi.Pokemon],
+	area pokeapi.NamedAPIResource[pokeapi.LocationArea],
+	details []pokeapi.VersionEncounterDetail,
+) {
+	if t.index == nil {
+		t.index = make(map[rowKey]int)
+	}
+
+	for _, vd := range details {
+		for _, e := range vd.EncounterDetails {
+			r := Row{
+				Pokemon:    p,
+				Area:       area,
+				Version:    vd.Version,
+				Method:     e.Method,
+				Conditions: slices.Clone(e.ConditionValues),
+				MinLevel:   e.MinLevel,
+				MaxLevel:   e.MaxLevel,
+				Chance:     e.Chance,
+			}
+			slices.SortFunc(
+				r.Conditions,
+				func(a, b pokeapi.NamedAPIResource[pokeapi.EncounterConditionValue]) int {
+					return cmp.Compare(a.Name, b.Name)
+				},
+			)
+
+			k := r.key()
+			i, ok := t.index[k]
+			if !ok {
+				t.index[k] = len(t.rows)
+				t.rows = append(t.rows, r)
+				continue
+			}
+
+			existing := &t.rows[i]
+			existing.MinLevel = min(existing.MinLevel, r.MinLevel)
+			existing.MaxLevel = max(existing.MaxLevel, r.MaxLevel)
+			existing.Chance += r.Chance
+		}
+	}
+}
+
+// AddLocationArea adds every encounter in the pokeapi.LocationArea to the
+// Table. Row.Area holds only the name of the pokeapi.LocationArea.
+func (t *Table) AddLocationArea(la *pokeapi.LocationArea) {
+	area := pokeapi.NamedAPIResource[pokeapi.LocationArea]{Name: la.Name}
+	for _, pe := range la.PokemonEncounters {
+		t.add(pe.Pokemon, area, pe.VersionDetails)
+	}
+}
+
+// AddPokemonEncounters adds the encounters of the Pokémon, as returned by
+// pokeapi.Client.GetPokemonEncounters, to the Table.
+func (t *Table) AddPokemonEncounters(
+	p pokeapi.NamedAPIResource[pokeapi.Pokemon],
+	encounters []pokeapi.PokemonLocationArea,
+) {
+	for _, pla := range encounters {
+		t.add(p, pla.LocationArea, pla.VersionDetails)
+	}
+}
+
+// FromLocationAreas builds a Table from the encounters in every
+// pokeapi.LocationArea.
+func FromLocationAreas(areas ...*pokeapi.LocationArea) *Table {
+	var t Table
+	for _, la := range areas {
+		t.AddLocationArea(la)
+	}
+	return &t
+}
+
+// ForPokemon retrieves the encounters of the pokeapi.Pokemon using the
+// pokeapi.Client, and builds a Table from them. Row.Pokemon holds only the name
+// of the pokeapi.Pokemon.
+func ForPokemon(ctx context.Context, c *pokeapi.Client, p *pokeapi.Pokemon) (*Table, error) {
+	encounters, err := p.GetEncounters(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("getting encounters for %q: %w", p.Name, err)
+	}
+
+	var t Table
+	t.AddPokemonEncounters(pokeapi.NamedAPIResource[pokeapi.Pokemon]{Name: p.Name}, encounters)
+	return &t, nil
+}
+
+// ResolveConditions retrieves every pokeapi.EncounterConditionValue in the
+// Table using pokeapi.GetAll, so that Query can tell which
+// pokeapi.EncounterCondition each belongs to.
+func (t *Table) ResolveConditions(ctx context.Context, c *pokeapi.Client, concurrency int) error {
+	var refs []pokeapi.NamedAPIResource[pokeapi.EncounterConditionValue]
+	for _, r := range t.rows {
+		for _, cv := range r.Conditions {
+			if _, ok := t.groups[cv.Name]; !ok {
+				refs = append(refs, cv)
+			}
+		}
+	}
+
+	values, err := pokeapi.GetAll(ctx, c, refs, concurrency)
+	if err != nil {
+		return fmt.Errorf("getting encounter condition values: %w", err)
+	}
+
+	if t.groups == nil {
+		t.groups = make(map[string]string, len(values))
+	}
+	for _, v := range values {
+		t.groups[v.Name] = v.Condition.Name
+	}
+	return nil
+}
+
+// group returns the name of the pokeapi.EncounterCondition the named value
+// belongs to. If it has not been resolved, the first segment of the value's
+// name is used, which matches PokéAPI's naming - "time-night" belongs to
+// "time", "swarm-yes" belongs to "swarm" and so on.
+func (t *Table) group(value string) string {
+	if g, ok := t.groups[value]; ok {
+		return g
+	}
+	g, _, _ := strings.Cut(value, "-")
+	return g
+}
+
+// A Query selects Row s from a Table. Empty fields match anything.
+type Query struct {
+	Pokemon string
+	Area    string
+	Version string
+	Method  string
+
+	// The names of the pokeapi.EncounterConditionValue s in effect, such as
+	// "time-night" or "swarm-yes".
+	//
+	// A Row matches if, for each of its Conditions, either that value is in
+	// effect or no value of the same pokeapi.EncounterCondition is specified.
+	// So querying for "time-night" excludes Rows requiring "time-day", but keeps
+	// Rows requiring "swarm-no". Use Table.ResolveConditions to group values
+	// exactly, rather than by name.
+	Conditions []string
+}
+
+func (t *Table) matches(q Query, r Row) bool {
+	if (q.Pokemon != "" && q.Pokemon != r.Pokemon.Name) ||
+		(q.Area != "" && q.Area != r.Area.Name) ||
+		(q.Version != "" && q.Version != r.Version.Name) ||
+		(q.Method != "" && q.Method != r.Method.Name) {
+		return false
+	}
+
+	specified := make(map[string]bool, len(q.Conditions))
+	for _, c := range q.Conditions {
+		specified[t.group(c)] = true
+	}
+	for _, c := range r.Conditions {
+		if specified[t.group(c.Name)] && !slices.Contains(q.Conditions, c.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Query returns the Row s in the Table matching the Query, ordered as Rows
+// does.
+//
+//	// where can I catch pikachu in yellow?
+//	t.Query(encounters.Query{Pokemon: "pikachu", Version: "yellow"})
+//
+//	// what appears in the eterna forest at night?
+//	t.Query(encounters.Query{Area: "eterna-forest-area", Conditions: []string{"time-night"}})
+func (t *Table) Query(q Query) []Row {
+	var res []Row
+	for _, r := range t.rows {
+		if t.matches(q, r) {
+			res = append(res, r)
+		}
+	}
+	slices.SortFunc(res, compareRows)
+	return res
+}
+
+// Areas returns the names of the areas the Pokémon can be encountered in, in
+// the named version. If version is "", every version is included.
+func (t *Table) Areas(pokemon, version string) []string {
+	var res []string
+	for _, r := range t.Query(Query{Pokemon: pokemon, Version: version}) {
+		if !slices.Contains(res, r.Area.Name) {
+			res = append(res, r.Area.Name)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
@@ -0,0 +1,218 @@
+package encounters_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/encounters"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+func slot(method string, minLevel, maxLevel, chance int, conditions ...string) pokeapi.Encounter {
+	e := pokeapi.Encounter{
+		MinLevel: minLevel,
+		MaxLevel: maxLevel,
+		Chance:   chance,
+		Method:   pokeapi.NamedAPIResource[pokeapi.EncounterMethod]{Name: method},
+	}
+	for _, c := range conditions {
+		e.ConditionValues = append(e.ConditionValues, pokeapi.NamedAPIResource[pokeapi.EncounterConditionValue]{Name: c})
+	}
+	return e
+}
+
+func inVersion(version string, slots ...pokeapi.Encounter) pokeapi.VersionEncounterDetail {
+	return pokeapi.VersionEncounterDetail{
+		Version:          pokeapi.NamedAPIResource[pokeapi.Version]{Name: version},
+		EncounterDetails: slots,
+	}
+}
+
+func pokemonEncounter(pokemon string, details ...pokeapi.VersionEncounterDetail) pokeapi.PokemonEncounter {
+	return pokeapi.PokemonEncounter{
+		Pokemon:        pokeapi.NamedAPIResource[pokeapi.Pokemon]{Name: pokemon},
+		VersionDetails: details,
+	}
+}
+
+func pokemonNames(rows []encounters.Row) []string {
+	res := make([]string, len(rows))
+	for i, r := range rows {
+		res[i] = r.Pokemon.Name
+	}
+	return res
+}
+
+// eternaForest is a cut-down eterna-forest-area, as of diamond & pearl.
+func eternaForest() *pokeapi.LocationArea {
+	return &pokeapi.LocationArea{
+		NamedIdentifier: pokeapi.NamedIdentifier{Name: "eterna-forest-area"},
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			pokemonEncounter(
+				"budew",
+				inVersion("diamond", slot("walk", 10, 10, 20), slot("walk", 12, 12, 10)),
+				inVersion("pearl", slot("walk", 10, 10, 20)),
+			),
+			pokemonEncounter(
+				"hoothoot",
+				inVersion("diamond", slot("walk", 10, 10, 10, "time-night"), slot("walk", 11, 11, 10, "time-night")),
+			),
+			pokemonEncounter(
+				"wurmple",
+				inVersion("diamond", slot("walk", 10, 10, 20, "time-day"), slot("walk", 10, 10, 20, "time-morning")),
+			),
+			pokemonEncounter(
+				"murkrow",
+				inVersion("diamond", slot("walk", 11, 11, 5, "story-progress-national-dex", "time-night")),
+			),
+			pokemonEncounter(
+				"bidoof",
+				inVersion("diamond", slot("walk", 10, 10, 10, "swarm-no")),
+			),
+		},
+	}
+}
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	t.Run(
+		"aggregates slots into rows",
+		func(t *testing.T) {
+			t.Parallel()
+
+			rows := encounters.FromLocationAreas(eternaForest()).Query(
+				encounters.Query{Pokemon: "budew", Version: "diamond"},
+			)
+			if len(rows) != 1 {
+				t.Fatalf("want 1 row; got %+v", rows)
+			}
+			if r := rows[0]; r.MinLevel != 10 || r.MaxLevel != 12 || r.Chance != 30 || r.Area.Name != "eterna-forest-area" {
+				t.Errorf("want levels 10-12 at 30%% in eterna-forest-area; got %+v", r)
+			}
+
+			// each set of conditions is its own row.
+			if rows := encounters.FromLocationAreas(eternaForest()).Query(encounters.Query{Pokemon: "wurmple"}); len(rows) != 2 {
+				t.Errorf("want a row for each condition; got %+v", rows)
+			}
+		},
+	)
+
+	t.Run(
+		"filters by the conditions in effect",
+		func(t *testing.T) {
+			t.Parallel()
+
+			table := encounters.FromLocationAreas(eternaForest())
+
+			got := pokemonNames(
+				table.Query(
+					encounters.Query{Area: "eterna-forest-area", Version: "diamond", Conditions: []string{"time-night"}},
+				),
+			)
+			if want := []string{"bidoof", "budew", "hoothoot", "murkrow"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("want %v at night; got %v", want, got)
+			}
+
+			got = pokemonNames(table.Query(encounters.Query{Version: "diamond", Conditions: []string{"time-day", "swarm-yes"}}))
+			if want := []string{"budew", "wurmple"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("want %v during the day in a swarm; got %v", want, got)
+			}
+
+			if got := table.Areas("budew", "pearl"); !reflect.DeepEqual(got, []string{"eterna-forest-area"}) {
+				t.Errorf("want budew in eterna-forest-area in pearl; got %v", got)
+			}
+			if got := table.Areas("hoothoot", "pearl"); len(got) != 0 {
+				t.Errorf("want no hoothoot in pearl; got %v", got)
+			}
+		},
+	)
+
+	t.Run(
+		"groups conditions by their resolved encounter condition",
+		func(t *testing.T) {
+			t.Parallel()
+
+			s, c := pokeapitest.NewServer(t)
+
+			value := func(name, condition string) pokeapi.NamedAPIResource[pokeapi.EncounterConditionValue] {
+				url := s.Add(
+					"/encounter-condition-value/"+name+"/",
+					pokeapi.EncounterConditionValue{
+						NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+						Condition:       pokeapi.NamedAPIResource[pokeapi.EncounterCondition]{Name: condition},
+					},
+				)
+				return pokeapi.NamedAPIResource[pokeapi.EncounterConditionValue]{
+					APIResource: pokeapi.APIResource[pokeapi.EncounterConditionValue]{URL: url},
+					Name:        name,
+				}
+			}
+
+			// the name of this value does not reflect its condition.
+			beforeDex := slot("walk", 10, 10, 10)
+			beforeDex.ConditionValues = append(beforeDex.ConditionValues, value("national-dex-no", "story-progress"))
+			afterDex := slot("walk", 10, 10, 10)
+			afterDex.ConditionValues = append(afterDex.ConditionValues, value("story-progress-national-dex", "story-progress"))
+
+			table := encounters.FromLocationAreas(
+				&pokeapi.LocationArea{
+					NamedIdentifier: pokeapi.NamedIdentifier{Name: "route-201-area"},
+					PokemonEncounters: []pokeapi.PokemonEncounter{
+						pokemonEncounter("starly", inVersion("diamond", beforeDex)),
+						pokemonEncounter("doduo", inVersion("diamond", afterDex)),
+					},
+				},
+			)
+			q := encounters.Query{Conditions: []string{"story-progress-national-dex"}}
+
+			if got := pokemonNames(table.Query(q)); !reflect.DeepEqual(got, []string{"doduo", "starly"}) {
+				t.Errorf("want both pokemon before resolving conditions; got %v", got)
+			}
+
+			if err := table.ResolveConditions(context.Background(), c, 2); err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if got := pokemonNames(table.Query(q)); !reflect.DeepEqual(got, []string{"doduo"}) {
+				t.Errorf("want only doduo after resolving conditions; got %v", got)
+			}
+		},
+	)
+}
+
+func TestForPokemon(t *testing.T) {
+	t.Parallel()
+
+	s, c := pokeapitest.NewServer(t)
+	s.Add(
+		"/pokemon/25/encounters",
+		[]pokeapi.PokemonLocationArea{
+			{
+				LocationArea:   pokeapi.NamedAPIResource[pokeapi.LocationArea]{Name: "viridian-forest-area"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{inVersion("yellow", slot("walk", 3, 5, 5))},
+			},
+			{
+				LocationArea:   pokeapi.NamedAPIResource[pokeapi.LocationArea]{Name: "power-plant-area"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{inVersion("red", slot("walk", 20, 24, 25))},
+			},
+		},
+	)
+
+	table, err := encounters.ForPokemon(
+		context.Background(),
+		c,
+		&pokeapi.Pokemon{NamedIdentifier: pokeapi.NamedIdentifier{Identifier: pokeapi.Identifier{ID: 25}, Name: "pikachu"}},
+	)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	if got := table.Areas("pikachu", "yellow"); !reflect.DeepEqual(got, []string{"viridian-forest-area"}) {
+		t.Errorf("want pikachu in viridian-forest-area in yellow; got %v", got)
+	}
+	if got := table.Areas("pikachu", ""); !reflect.DeepEqual(got, []string{"power-plant-area", "viridian-forest-area"}) {
+		t.Errorf("want pikachu in both areas across versions; got %v", got)
+	}
+}
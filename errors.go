@@ -13,6 +13,19 @@ var (
 	// ErrNotFound is the error returned when attempting to retrieve a resource
 	// that does not exist.
 	ErrNotFound = HTTPError{Code: 404}
+
+	// ErrMalformedResourceURL is returned by ParseResourceURL when the URL does
+	// not refer to a single resource by its ID.
+	ErrMalformedResourceURL = fmt.Errorf("malformed resource url")
+
+	// ErrLevelOutOfRange is returned by the GrowthRate experience helpers when a
+	// level is not covered by GrowthRate.Levels, or is otherwise invalid.
+	ErrLevelOutOfRange = fmt.Errorf("level out of range")
+
+	// ErrInvalidInput is returned by the calculators in this module's
+	// subpackages when an argument - such as a generation, level or HP value -
+	// is out of the range they support.
+	ErrInvalidInput = fmt.Errorf("invalid input")
 )
 
 // HTTPError represents an error returned by a failed HTTP request. As a special
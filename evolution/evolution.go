@@ -0,0 +1,376 @@
+// Package evolution turns pokeapi.EvolutionDetail s into structured
+// requirements, and renders those requirements as sentences for players - such
+// as "Level 20 at night while holding Oval Stone".
+package evolution
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// A Kind identifies the type of a Condition.
+type Kind string
+
+// The Kind s of Condition that may be placed on an evolution, one for each
+// optional field of pokeapi.EvolutionDetail.
+const (
+	MinLevel              Kind = "min-level"
+	Item                  Kind = "item"
+	HeldItem              Kind = "held-item"
+	KnownMove             Kind = "known-move"
+	KnownMoveType         Kind = "known-move-type"
+	Location              Kind = "location"
+	MinHappiness          Kind = "min-happiness"
+	MinBeauty             Kind = "min-beauty"
+	MinAffection          Kind = "min-affection"
+	NeedsOverworldRain    Kind = "needs-overworld-rain"
+	PartySpecies          Kind = "party-species"
+	PartyType             Kind = "party-type"
+	RelativePhysicalStats Kind = "relative-physical-stats"
+	TimeOfDay             Kind = "time-of-day"
+	TradeSpecies          Kind = "trade-species"
+	TurnUpsideDown        Kind = "turn-upside-down"
+	Gender                Kind = "gender"
+)
+
+// The names of the pokeapi.EvolutionTrigger s known to PokéAPI.
+const (
+	LevelUp           = "level-up"
+	Trade             = "trade"
+	UseItem           = "use-item"
+	Shed              = "shed"
+	Spin              = "spin"
+	TowerOfDarkness   = "tower-of-darkness"
+	TowerOfWaters     = "tower-of-waters"
+	ThreeCriticalHits = "three-critical-hits"
+	TakeDamage        = "take-damage"
+	AgileStyleMove    = "agile-style-move"
+	StrongStyleMove   = "strong-style-move"
+	RecoilDamage      = "recoil-damage"
+	Other             = "other"
+)
+
+// The values of pokeapi.EvolutionDetail.Gender.
+const (
+	Female = 1
+	Male   = 2
+)
+
+// A Condition is a single requirement that must be met for an evolution to
+// occur.
+type Condition struct {
+	Kind Kind
+
+	// The numeric value of the Condition, for MinLevel, MinHappiness, MinBeauty,
+	// MinAffection, RelativePhysicalStats and Gender.
+	Value int
+
+	// The kebab-case name of the resource the Condition refers to, or the
+	// time of day for TimeOfDay.
+	Name string
+
+	// names retrieves the localized names of the resource the Condition refers
+	// to. It is nil if there is no such resource.
+	names func(ctx context.Context, c *pokeapi.Client) ([]pokeapi.Name, error)
+}
+
+// A Requirement is the full set of conditions for a single
+// pokeapi.EvolutionDetail.
+type Requirement struct {
+	Trigger    pokeapi.NamedAPIResource[pokeapi.EvolutionTrigger]
+	Conditions []Condition
+}
+
+// Get returns the Condition of the given Kind, if the Requirement has one.
+func (r Requirement) Get(k Kind) (Condition, bool) {
+	for _, c := range r.Conditions {
+		if c.Kind == k {
+			return c, true
+		}
+	}
+	return Condition{}, false
+}
+
+func resourceCondition[T any](
+	k Kind,
+	ref *pokeapi.NamedAPIResource[T],
+	names func(*T) []pokeapi.Name,
+) Condition {
+	return Condition{
+		Kind: k,
+		Name: ref.Name,
+		names: func(ctx context.Context, c *pokeapi.Client) ([]pokeapi.Name, error) {
+			v, err := ref.Get(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			return names(v), nil
+		},
+	}
+}
+
+func itemNames(i *pokeapi.Item) []pokeapi.Name                { return i.Names }
+func moveNames(m *pokeapi.Move) []pokeapi.Name                { return m.Names }
+func typeNames(t *pokeapi.Type) []pokeapi.Name                { return t.Names }
+func locationNames(l *pokeapi.Location) []pokeapi.Name        { return l.Names }
+func speciesNames(s *pokeapi.PokemonSpecies) []pokeapi.Name   { return s.Names }
+func triggerNames(t *pokeapi.EvolutionTrigger) []pokeapi.Name { return t.Names }
+
+// Parse converts the pokeapi.EvolutionDetail into a Requirement. Conditions are
+// ordered as they are rendered: the level, item or trade partner, then when &
+// where, then what the Pokémon must be holding or know, then everything else.
+func Parse(d pokeapi.EvolutionDetail) Requirement {
+	var (
+		r      = Requirement{Trigger: d.Trigger}
+		add    = func(c Condition) { r.Conditions = append(r.Conditions, c) }
+		addInt = func(k Kind, v *int) {
+			if v != nil {
+				add(Condition{Kind: k, Value: *v})
+			}
+		}
+	)
+
+	addInt(MinLevel, d.MinLevel)
+	if d.Item != nil {
+		add(resourceCondition(Item, d.Item, itemNames))
+	}
+	if d.TradeSpecies != nil {
+		add(resourceCondition(TradeSpecies, d.TradeSpecies, speciesNames))
+	}
+	addInt(Gender, d.Gender)
+	if d.TimeOfDay != "" {
+		add(Condition{Kind: TimeOfDay, Name: d.TimeOfDay})
+	}
+	if d.Location != nil {
+		add(resourceCondition(Location, d.Location, locationNames))
+	}
+	if d.HeldItem != nil {
+		add(resourceCondition(HeldItem, d.HeldItem, itemNames))
+	}
+	if d.KnownMove != nil {
+		add(resourceCondition(KnownMove, d.KnownMove, moveNames))
+	}
+	if d.KnownMoveType != nil {
+		add(resourceCondition(KnownMoveType, d.KnownMoveType, typeNames))
+	}
+	addInt(MinHappiness, d.MinHappiness)
+	addInt(MinBeauty, d.MinBeauty)
+	addInt(MinAffection, d.MinAffection)
+	if d.PartySpecies != nil {
+		add(resourceCondition(PartySpecies, d.PartySpecies, speciesNames))
+	}
+	if d.PartyType != nil {
+		add(resourceCondition(PartyType, d.PartyType, typeNames))
+	}
+	addInt(RelativePhysicalStats, d.RelativePhysicalStats)
+	if d.NeedsOverworldRain {
+		add(Condition{Kind: NeedsOverworldRain})
+	}
+	if d.TurnUpsideDown {
+		add(Condition{Kind: TurnUpsideDown})
+	}
+
+	return r
+}
+
+// Phrases are the fmt format strings used to render a Requirement, keyed by
+// trigger name, Kind or one of the more specific keys used by DefaultPhrases.
+// Verbs are filled with the localized name of the resource referred to, or the
+// numeric value of the Condition.
+type Phrases map[string]string
+
+// DefaultPhrases are the English Phrases used by a Renderer unless overridden.
+var DefaultPhrases = Phrases{
+	LevelUp:                                  "Level up",
+	LevelUp + "+" + string(MinLevel):         "Level %d",
+	Trade:                                    "Trade",
+	Trade + "+" + string(TradeSpecies):       "Trade for %s",
+	UseItem:                                  "Use an item",
+	UseItem + "+" + string(Item):             "Use %s",
+	Shed:                                     "Level up with an empty party slot and a spare Poké Ball",
+	Spin:                                     "Spin around",
+	TowerOfDarkness:                          "Train in the Tower of Darkness",
+	TowerOfWaters:                            "Train in the Tower of Waters",
+	ThreeCriticalHits:                        "Land three critical hits in one battle",
+	TakeDamage:                               "Take at least 49 damage without fainting, then travel under the stone bridge in the Dusty Bowl",
+	AgileStyleMove:                           "Use a move 20 times in the Agile Style",
+	AgileStyleMove + "+" + string(KnownMove): "Use %s 20 times in the Agile Style",
+	StrongStyleMove:                          "Use a move 20 times in the Strong Style",
+	StrongStyleMove + "+" + string(KnownMove): "Use %s 20 times in the Strong Style",
+	RecoilDamage: "Lose at least 294 HP from recoil damage without fainting",
+
+	string(Gender) + "+1":                 "if female",
+	string(Gender) + "+2":                 "if male",
+	string(TimeOfDay) + "+day":            "during the day",
+	string(TimeOfDay) + "+night":          "at night",
+	string(TimeOfDay) + "+dusk":           "at dusk",
+	string(TimeOfDay):                     "during the %s",
+	string(Location):                      "at %s",
+	string(HeldItem):                      "while holding %s",
+	string(KnownMove):                     "knowing %s",
+	string(KnownMoveType):                 "knowing a %s-type move",
+	string(MinHappiness):                  "with at least %d happiness",
+	string(MinBeauty):                     "with at least %d beauty",
+	string(MinAffection):                  "with at least %d affection",
+	string(PartySpecies):                  "with %s in the party",
+	string(PartyType):                     "with a %s-type Pokémon in the party",
+	string(RelativePhysicalStats) + "+1":  "with Attack higher than Defense",
+	string(RelativePhysicalStats) + "+0":  "with Attack equal to Defense",
+	string(RelativePhysicalStats) + "+-1": "with Attack lower than Defense",
+	string(NeedsOverworldRain):            "while it is raining",
+	string(TurnUpsideDown):                "while holding the console upside down",
+	string(MinLevel):                      "from level %d",
+	string(Item):                          "using %s",
+	string(TradeSpecies):                  "for %s",
+}
+
+// RendererOpts configure a Renderer.
+type RendererOpts struct {
+	// The pokeapi.Language names to render resource names in, most preferred
+	// first. See pokeapi.Localize for how they are matched.
+	Languages []string
+
+	// Phrases to use in place of the DefaultPhrases. Any phrase not set is taken
+	// from DefaultPhrases.
+	Phrases Phrases
+}
+
+// A Renderer renders Requirement s as sentences, resolving the names of the
+// resources they refer to using a pokeapi.Client.
+type Renderer struct {
+	client    *pokeapi.Client
+	languages []string
+	phrases   Phrases
+}
+
+// NewRenderer creates a Renderer that resolves names using the
+// pokeapi.Client. It is safe to use as NewRenderer(c, nil) to render in
+// English.
+func NewRenderer(c *pokeapi.Client, opts *RendererOpts) *Renderer {
+	r := Renderer{client: c, phrases: DefaultPhrases}
+
+	if opts != nil {
+		r.languages = opts.Languages
+		if len(opts.Phrases) != 0 {
+			r.phrases = make(Phrases, len(DefaultPhrases)+len(opts.Phrases))
+			for k, v := range DefaultPhrases {
+				r.phrases[k] = v
+			}
+			for k, v := range opts.Phrases {
+				r.phrases[k] = v
+			}
+		}
+	}
+
+	return &r
+}
+
+// localize returns the name in the most preferred language available, or
+// fallback if there is none.
+func (r *Renderer) localize(names []pokeapi.Name, fallback string) string {
+	if n, ok := pokeapi.Localize(names, r.languages...); ok {
+		return n.Name
+	}
+	return fallback
+}
+
+// Name returns the localized name of the resource the Condition refers to. If
+// the Condition does not refer to a resource, its Name is returned.
+func (r *Renderer) Name(ctx context.Context, c Condition) (string, error) {
+	if c.names == nil {
+		return c.Name, nil
+	}
+
+	names, err := c.names(ctx, r.client)
+	if err != nil {
+		return "", fmt.Errorf("getting names for %s %q: %w", c.Kind, c.Name, err)
+	}
+	return r.localize(names, c.Name), nil
+}
+
+// phrase formats the phrase for the Condition, using the more specific key
+// where there is a phrase for it.
+func (r *Renderer) phrase(ctx context.Context, key string, c Condition) (string, error) {
+	if p, ok := r.phrases[fmt.Sprintf("%s+%v", key, c.Value)]; ok && c.Name == "" {
+		return p, nil
+	}
+	if p, ok := r.phrases[fmt.Sprintf("%s+%s", key, c.Name)]; ok && c.names == nil {
+		return p, nil
+	}
+
+	p, ok := r.phrases[key]
+	if !ok {
+		return "", fmt.Errorf("no phrase for %q", key)
+	}
+
+	switch {
+	case c.names != nil || c.Name != "":
+		name, err := r.Name(ctx, c)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(p, name), nil
+	case strings.Contains(p, "%d"):
+		return fmt.Sprintf(p, c.Value), nil
+	default:
+		return p, nil
+	}
+}
+
+// triggerPhrase renders the trigger of the Requirement, returning the Kind of
+// Condition it made use of (if any).
+func (r *Renderer) triggerPhrase(ctx context.Context, req Requirement) (string, Kind, error) {
+	trigger := req.Trigger.Name
+	for _, k := range []Kind{MinLevel, Item, TradeSpecies, KnownMove} {
+		key := trigger + "+" + string(k)
+		if _, ok := r.phrases[key]; !ok {
+			continue
+		}
+		if c, ok := req.Get(k); ok {
+			p, err := r.phrase(ctx, key, c)
+			return p, k, err
+		}
+	}
+
+	if p, ok := r.phrases[trigger]; ok {
+		return p, "", nil
+	}
+
+	// unknown triggers are rendered using their own localized names.
+	names, err := req.Trigger.Get(ctx, r.client)
+	if err != nil {
+		return "", "", fmt.Errorf("getting names for trigger %q: %w", trigger, err)
+	}
+	return r.localize(triggerNames(names), trigger), "", nil
+}
+
+// Render renders the Requirement as a sentence, such as "Level 20 at night
+// while holding Oval Stone".
+func (r *Renderer) Render(ctx context.Context, req Requirement) (string, error) {
+	head, used, err := r.triggerPhrase(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{head}
+	for _, c := range req.Conditions {
+		if c.Kind == used {
+			continue
+		}
+
+		p, err := r.phrase(ctx, string(c.Kind), c)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, p)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// RenderDetail parses and renders the pokeapi.EvolutionDetail.
+func (r *Renderer) RenderDetail(ctx context.Context, d pokeapi.EvolutionDetail) (string, error) {
+	return r.Render(ctx, Parse(d))
+}
@@ -0,0 +1,243 @@
+package evolution_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/evolution"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+func intPtr(i int) *int { return &i }
+
+func names(en, fr string) []pokeapi.Name {
+	res := []pokeapi.Name{
+		{Name: en, Language: pokeapi.NamedAPIResource[pokeapi.Language]{Name: "en"}},
+	}
+	if fr != "" {
+		res = append(res, pokeapi.Name{Name: fr, Language: pokeapi.NamedAPIResource[pokeapi.Language]{Name: "fr"}})
+	}
+	return res
+}
+
+func namedRef[T any](url, name string) *pokeapi.NamedAPIResource[T] {
+	return &pokeapi.NamedAPIResource[T]{APIResource: pokeapi.APIResource[T]{URL: url}, Name: name}
+}
+
+func TestRenderer(t *testing.T) {
+	t.Parallel()
+
+	s, c := pokeapitest.NewServer(t)
+
+	// every trigger is served up front, as subtests run in parallel with the
+	// server.
+	triggers := make(map[string]pokeapi.NamedAPIResource[pokeapi.EvolutionTrigger])
+	for _, name := range []string{
+		evolution.LevelUp, evolution.Trade, evolution.UseItem, evolution.Shed, evolution.Spin,
+		evolution.TowerOfDarkness, evolution.TowerOfWaters, evolution.ThreeCriticalHits, evolution.TakeDamage,
+		evolution.AgileStyleMove, evolution.StrongStyleMove, evolution.RecoilDamage, evolution.Other,
+	} {
+		url := s.Add("/evolution-trigger/"+name+"/", pokeapi.EvolutionTrigger{Names: names("Other", "Autre")})
+		triggers[name] = *namedRef[pokeapi.EvolutionTrigger](url, name)
+	}
+	trigger := func(name string) pokeapi.NamedAPIResource[pokeapi.EvolutionTrigger] { return triggers[name] }
+
+	var (
+		ovalStone       = namedRef[pokeapi.Item](s.Add("/item/110/", pokeapi.Item{Names: names("Oval Stone", "Pierre Ovale")}), "oval-stone")
+		thunderStone    = namedRef[pokeapi.Item](s.Add("/item/83/", pokeapi.Item{Names: names("Thunder Stone", "")}), "thunder-stone")
+		strawberrySweet = namedRef[pokeapi.Item](s.Add("/item/1109/", pokeapi.Item{Names: names("Strawberry Sweet", "")}), "strawberry-sweet")
+		shelmet         = namedRef[pokeapi.PokemonSpecies](s.Add("/pokemon-species/616/", pokeapi.PokemonSpecies{Names: names("Shelmet", "Escargaume")}), "shelmet")
+		psyshieldBash   = namedRef[pokeapi.Move](s.Add("/move/828/", pokeapi.Move{Names: names("Psyshield Bash", "")}), "psyshield-bash")
+		barbBarrage     = namedRef[pokeapi.Move](s.Add("/move/839/", pokeapi.Move{Names: names("Barb Barrage", "")}), "barb-barrage")
+		fairy           = namedRef[pokeapi.Type](s.Add("/type/18/", pokeapi.Type{Names: names("Fairy", "Fée")}), "fairy")
+		dark            = namedRef[pokeapi.Type](s.Add("/type/17/", pokeapi.Type{Names: names("Dark", "Ténèbres")}), "dark")
+		remoraid        = namedRef[pokeapi.PokemonSpecies](s.Add("/pokemon-species/223/", pokeapi.PokemonSpecies{Names: names("Remoraid", "")}), "remoraid")
+		mtCoronet       = namedRef[pokeapi.Location](s.Add("/location/10/", pokeapi.Location{Names: names("Mt. Coronet", "Mont Couronné")}), "mt-coronet")
+	)
+
+	for _, tc := range []struct {
+		name   string
+		detail pokeapi.EvolutionDetail
+		want   string
+	}{
+		{
+			name:   "level-up with a level, time & held item",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.LevelUp), MinLevel: intPtr(20), TimeOfDay: "night", HeldItem: ovalStone},
+			want:   "Level 20 at night while holding Oval Stone",
+		},
+		{
+			name:   "level-up with happiness during the day",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.LevelUp), MinHappiness: intPtr(160), TimeOfDay: "day"},
+			want:   "Level up during the day with at least 160 happiness",
+		},
+		{
+			name: "level-up with gender and location",
+			detail: pokeapi.EvolutionDetail{
+				Trigger: trigger(evolution.LevelUp), MinLevel: intPtr(20), Gender: intPtr(evolution.Male), Location: mtCoronet,
+			},
+			want: "Level 20 if male at Mt. Coronet",
+		},
+		{
+			name: "level-up with relative stats",
+			detail: pokeapi.EvolutionDetail{
+				Trigger: trigger(evolution.LevelUp), MinLevel: intPtr(20), RelativePhysicalStats: intPtr(-1),
+			},
+			want: "Level 20 with Attack lower than Defense",
+		},
+		{
+			name: "level-up with party, move type, rain and upside down",
+			detail: pokeapi.EvolutionDetail{
+				Trigger:            trigger(evolution.LevelUp),
+				MinLevel:           intPtr(30),
+				KnownMoveType:      fairy,
+				MinAffection:       intPtr(2),
+				PartySpecies:       remoraid,
+				PartyType:          dark,
+				NeedsOverworldRain: true,
+				TurnUpsideDown:     true,
+			},
+			want: "Level 30 knowing a Fairy-type move with at least 2 affection with Remoraid in the party" +
+				" with a Dark-type Pokémon in the party while it is raining while holding the console upside down",
+		},
+		{
+			name:   "trade",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.Trade)},
+			want:   "Trade",
+		},
+		{
+			name:   "trade for a species",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.Trade), TradeSpecies: shelmet},
+			want:   "Trade for Shelmet",
+		},
+		{
+			name:   "use-item",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.UseItem), Item: thunderStone, Gender: intPtr(evolution.Female)},
+			want:   "Use Thunder Stone if female",
+		},
+		{
+			name:   "shed",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.Shed)},
+			want:   "Level up with an empty party slot and a spare Poké Ball",
+		},
+		{
+			name:   "spin",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.Spin), HeldItem: strawberrySweet},
+			want:   "Spin around while holding Strawberry Sweet",
+		},
+		{
+			name:   "tower-of-darkness",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.TowerOfDarkness)},
+			want:   "Train in the Tower of Darkness",
+		},
+		{
+			name:   "tower-of-waters",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.TowerOfWaters)},
+			want:   "Train in the Tower of Waters",
+		},
+		{
+			name:   "three-critical-hits",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.ThreeCriticalHits)},
+			want:   "Land three critical hits in one battle",
+		},
+		{
+			name:   "take-damage",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.TakeDamage)},
+			want:   "Take at least 49 damage without fainting, then travel under the stone bridge in the Dusty Bowl",
+		},
+		{
+			name:   "agile-style-move",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.AgileStyleMove), KnownMove: psyshieldBash},
+			want:   "Use Psyshield Bash 20 times in the Agile Style",
+		},
+		{
+			name:   "strong-style-move",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.StrongStyleMove), KnownMove: barbBarrage},
+			want:   "Use Barb Barrage 20 times in the Strong Style",
+		},
+		{
+			name:   "recoil-damage",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.RecoilDamage)},
+			want:   "Lose at least 294 HP from recoil damage without fainting",
+		},
+		{
+			name:   "other",
+			detail: pokeapi.EvolutionDetail{Trigger: trigger(evolution.Other)},
+			want:   "Other",
+		},
+	} {
+		t.Run(
+			tc.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				got, err := evolution.NewRenderer(c, nil).RenderDetail(context.Background(), tc.detail)
+				if got != tc.want || err != nil {
+					t.Errorf("want (%q, nil); got (%q, %v)", tc.want, got, err)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"localizes names and phrases, falling back to english",
+		func(t *testing.T) {
+			t.Parallel()
+
+			r := evolution.NewRenderer(
+				c,
+				&evolution.RendererOpts{
+					Languages: []string{"fr"},
+					Phrases: evolution.Phrases{
+						evolution.LevelUp + "+" + string(evolution.MinLevel): "Niveau %d",
+						string(evolution.HeldItem):                           "en tenant %s",
+					},
+				},
+			)
+
+			got, err := r.RenderDetail(
+				context.Background(),
+				pokeapi.EvolutionDetail{Trigger: trigger(evolution.LevelUp), MinLevel: intPtr(20), HeldItem: ovalStone},
+			)
+			if want := "Niveau 20 en tenant Pierre Ovale"; got != want || err != nil {
+				t.Errorf("want (%q, nil); got (%q, %v)", want, got, err)
+			}
+
+			got, err = r.RenderDetail(
+				context.Background(),
+				pokeapi.EvolutionDetail{Trigger: trigger(evolution.UseItem), Item: thunderStone},
+			)
+			if want := "Use Thunder Stone"; got != want || err != nil {
+				t.Errorf("want (%q, nil); got (%q, %v)", want, got, err)
+			}
+
+			got, err = r.RenderDetail(context.Background(), pokeapi.EvolutionDetail{Trigger: trigger(evolution.Other)})
+			if want := "Autre"; got != want || err != nil {
+				t.Errorf("want (%q, nil); got (%q, %v)", want, got, err)
+			}
+		},
+	)
+
+	t.Run(
+		"parses structured conditions",
+		func(t *testing.T) {
+			t.Parallel()
+
+			req := evolution.Parse(
+				pokeapi.EvolutionDetail{Trigger: trigger(evolution.LevelUp), MinLevel: intPtr(20), TimeOfDay: "night"},
+			)
+			if len(req.Conditions) != 2 {
+				t.Fatalf("want 2 conditions; got %+v", req.Conditions)
+			}
+			if c, ok := req.Get(evolution.MinLevel); !ok || c.Value != 20 {
+				t.Errorf("want a min-level condition of 20; got (%+v, %t)", c, ok)
+			}
+			if c, ok := req.Get(evolution.TimeOfDay); !ok || c.Name != "night" {
+				t.Errorf("want a time-of-day condition of night; got (%+v, %t)", c, ok)
+			}
+			if c, ok := req.Get(evolution.HeldItem); ok {
+				t.Errorf("want no held-item condition; got %+v", c)
+			}
+		},
+	)
+}
@@ -41,3 +41,130 @@ type EvolutionTrigger struct {
 	Names          []Name                             `json:"names"`
 	PokemonSpecies []NamedAPIResource[PokemonSpecies] `json:"pokemon_species"`
 }
+
+// Walk calls fn for every ChainLink in the EvolutionChain, in depth-first
+// order, along with its depth in the chain - 0 for the first stage. Walking
+// stops early if fn returns false.
+func (c EvolutionChain) Walk(fn func(link *ChainLink, depth int) bool) {
+	if c.Chain != nil {
+		c.Chain.walk(nil, 0, func(link, _ *ChainLink, depth int) bool { return fn(link, depth) })
+	}
+}
+
+// walk implements Walk, also passing each ChainLink's parent to fn. It returns
+// false if walking was stopped early.
+func (cl *ChainLink) walk(parent *ChainLink, depth int, fn func(link, parent *ChainLink, depth int) bool) bool {
+	if !fn(cl, parent, depth) {
+		return false
+	}
+	for i := range cl.EvolvesTo {
+		if !cl.EvolvesTo[i].walk(cl, depth+1, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Species returns every species in the EvolutionChain, in depth-first order.
+func (c EvolutionChain) Species() []NamedAPIResource[PokemonSpecies] {
+	var res []NamedAPIResource[PokemonSpecies]
+	c.Walk(
+		func(link *ChainLink, _ int) bool {
+			res = append(res, link.Species)
+			return true
+		},
+	)
+	return res
+}
+
+// Stages returns every species in the EvolutionChain grouped by how many times
+// they have evolved. Branching chains have multiple species in a stage - such
+// as every Eeveelution in the second. Baby Pokémon are the first stage of the
+// chains they appear in.
+func (c EvolutionChain) Stages() [][]NamedAPIResource[PokemonSpecies] {
+	var res [][]NamedAPIResource[PokemonSpecies]
+	c.Walk(
+		func(link *ChainLink, depth int) bool {
+			if depth == len(res) {
+				res = append(res, nil)
+			}
+			res[depth] = append(res[depth], link.Species)
+			return true
+		},
+	)
+	return res
+}
+
+// Find returns the ChainLink for the named species, or nil if it is not in the
+// EvolutionChain.
+func (c EvolutionChain) Find(species string) *ChainLink {
+	path := c.PathTo(species)
+	if len(path) == 0 {
+		return nil
+	}
+	return path[len(path)-1]
+}
+
+// PathTo returns the ChainLink s leading from the first stage of the
+// EvolutionChain to the named species, inclusive. It returns nil if the species
+// is not in the EvolutionChain.
+func (c EvolutionChain) PathTo(species string) []*ChainLink {
+	var (
+		path  []*ChainLink
+		found bool
+	)
+	c.Walk(
+		func(link *ChainLink, depth int) bool {
+			path = append(path[:depth], link)
+			found = link.Species.Name == species
+			return !found
+		},
+	)
+
+	if !found {
+		return nil
+	}
+	return path
+}
+
+// PreEvolutionOf returns the ChainLink of the species the named species evolves
+// from. It returns false if the named species is the first stage of the
+// EvolutionChain or is not in it.
+func (c EvolutionChain) PreEvolutionOf(species string) (*ChainLink, bool) {
+	path := c.PathTo(species)
+	if len(path) < 2 {
+		return nil, false
+	}
+	return path[len(path)-2], true
+}
+
+// EvolutionsOf returns the ChainLink s of the species the named species evolves
+// into. Each ChainLink's EvolutionDetails list the different ways that
+// evolution can be triggered.
+func (c EvolutionChain) EvolutionsOf(species string) []ChainLink {
+	if link := c.Find(species); link != nil {
+		return link.EvolvesTo
+	}
+	return nil
+}
+
+// EggSpecies returns the species that hatch from eggs laid by the species in
+// the EvolutionChain. If the first stage is a baby Pokémon that is only
+// hatched when a parent holds the EvolutionChain.BabyTriggerItem (such as
+// Azurill and the Sea Incense), the second stage hatches instead unless
+// holdingBabyTriggerItem is set.
+func (c EvolutionChain) EggSpecies(holdingBabyTriggerItem bool) []NamedAPIResource[PokemonSpecies] {
+	if c.Chain == nil {
+		return nil
+	}
+
+	if !c.Chain.IsBaby || c.BabyTriggerItem == nil || holdingBabyTriggerItem {
+		return []NamedAPIResource[PokemonSpecies]{c.Chain.Species}
+	}
+
+	res := make([]NamedAPIResource[PokemonSpecies], len(c.Chain.EvolvesTo))
+	for i, link := range c.Chain.EvolvesTo {
+		res[i] = link.Species
+	}
+	return res
+}
@@ -0,0 +1,132 @@
+package pokeapi_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+func link(species string, isBaby bool, evolvesTo ...pokeapi.ChainLink) pokeapi.ChainLink {
+	return pokeapi.ChainLink{
+		IsBaby:    isBaby,
+		Species:   pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{Name: species},
+		EvolvesTo: evolvesTo,
+	}
+}
+
+func speciesNames(refs []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]) []string {
+	res := make([]string, len(refs))
+	for i, r := range refs {
+		res[i] = r.Name
+	}
+	return res
+}
+
+func TestEvolutionChain(t *testing.T) {
+	t.Parallel()
+
+	var (
+		eeveeLink = link(
+			"eevee", false,
+			link("vaporeon", false), link("jolteon", false), link("flareon", false), link("espeon", false),
+		)
+		eevee = pokeapi.EvolutionChain{Chain: &eeveeLink}
+
+		azurillLink = link("azurill", true, link("marill", false, link("azumarill", false)))
+		azurill     = pokeapi.EvolutionChain{
+			BabyTriggerItem: &pokeapi.NamedAPIResource[pokeapi.Item]{Name: "sea-incense"},
+			Chain:           &azurillLink,
+		}
+	)
+	// espeon has more than one way to evolve.
+	eeveeLink.EvolvesTo[3].EvolutionDetails = []pokeapi.EvolutionDetail{{TimeOfDay: "day"}, {TimeOfDay: "day"}}
+
+	t.Run(
+		"flattens species and groups them into stages",
+		func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := speciesNames(azurill.Species()), []string{"azurill", "marill", "azumarill"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("want species %v; got %v", want, got)
+			}
+
+			var got [][]string
+			for _, s := range eevee.Stages() {
+				got = append(got, speciesNames(s))
+			}
+			want := [][]string{{"eevee"}, {"vaporeon", "jolteon", "flareon", "espeon"}}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("want stages %v; got %v", want, got)
+			}
+		},
+	)
+
+	t.Run(
+		"finds paths, pre-evolutions and evolutions",
+		func(t *testing.T) {
+			t.Parallel()
+
+			var path []string
+			for _, l := range azurill.PathTo("azumarill") {
+				path = append(path, l.Species.Name)
+			}
+			if want := []string{"azurill", "marill", "azumarill"}; !reflect.DeepEqual(path, want) {
+				t.Errorf("want path %v; got %v", want, path)
+			}
+			if p := eevee.PathTo("pikachu"); p != nil {
+				t.Errorf("want no path to a species outside the chain; got %v", p)
+			}
+
+			if pre, ok := eevee.PreEvolutionOf("espeon"); !ok || pre.Species.Name != "eevee" {
+				t.Errorf("want espeon to evolve from eevee; got (%v, %t)", pre, ok)
+			}
+			if pre, ok := eevee.PreEvolutionOf("eevee"); ok {
+				t.Errorf("want eevee to have no pre-evolution; got %v", pre)
+			}
+
+			evos := eevee.EvolutionsOf("eevee")
+			if len(evos) != 4 || len(evos[3].EvolutionDetails) != 2 {
+				t.Errorf("want 4 evolutions with espeon's 2 evolution details; got %v", evos)
+			}
+			if evos := eevee.EvolutionsOf("jolteon"); len(evos) != 0 {
+				t.Errorf("want jolteon not to evolve; got %v", evos)
+			}
+		},
+	)
+
+	t.Run(
+		"walks every link with its depth and stops early",
+		func(t *testing.T) {
+			t.Parallel()
+
+			var visited []string
+			eevee.Walk(
+				func(l *pokeapi.ChainLink, depth int) bool {
+					visited = append(visited, l.Species.Name)
+					return l.Species.Name != "jolteon"
+				},
+			)
+			if want := []string{"eevee", "vaporeon", "jolteon"}; !reflect.DeepEqual(visited, want) {
+				t.Errorf("want visits %v; got %v", want, visited)
+			}
+		},
+	)
+
+	t.Run(
+		"works out which species hatch from eggs",
+		func(t *testing.T) {
+			t.Parallel()
+
+			if got := speciesNames(azurill.EggSpecies(false)); !reflect.DeepEqual(got, []string{"marill"}) {
+				t.Errorf("want marill to hatch without sea incense; got %v", got)
+			}
+			if got := speciesNames(azurill.EggSpecies(true)); !reflect.DeepEqual(got, []string{"azurill"}) {
+				t.Errorf("want azurill to hatch with sea incense; got %v", got)
+			}
+			if got := speciesNames(eevee.EggSpecies(false)); !reflect.DeepEqual(got, []string{"eevee"}) {
+				t.Errorf("want eevee to hatch; got %v", got)
+			}
+		},
+	)
+}
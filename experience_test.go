@@ -0,0 +1,146 @@
+package pokeapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// growthRates builds the six GrowthRate s from their formulas, as PokéAPI
+// does - so level 1 always needs 0 experience.
+func growthRates() map[string]pokeapi.GrowthRate {
+	formulas := map[string]func(n int) int{
+		pokeapi.GrowthRateErratic: func(n int) int {
+			switch {
+			case n < 50:
+				return n * n * n * (100 - n) / 50
+			case n < 68:
+				return n * n * n * (150 - n) / 100
+			case n < 98:
+				return n * n * n * ((1911 - 10*n) / 3) / 500
+			default:
+				return n * n * n * (160 - n) / 100
+			}
+		},
+		pokeapi.GrowthRateFast:       func(n int) int { return 4 * n * n * n / 5 },
+		pokeapi.GrowthRateMediumFast: func(n int) int { return n * n * n },
+		pokeapi.GrowthRateMediumSlow: func(n int) int { return 6*n*n*n/5 - 15*n*n + 100*n - 140 },
+		pokeapi.GrowthRateSlow:       func(n int) int { return 5 * n * n * n / 4 },
+		pokeapi.GrowthRateFluctuating: func(n int) int {
+			switch {
+			case n < 15:
+				return n * n * n * ((n+1)/3 + 24) / 50
+			case n < 36:
+				return n * n * n * (n + 14) / 50
+			default:
+				return n * n * n * (n/2 + 32) / 50
+			}
+		},
+	}
+
+	res := make(map[string]pokeapi.GrowthRate, len(formulas))
+	for name, f := range formulas {
+		gr := pokeapi.GrowthRate{NamedIdentifier: pokeapi.NamedIdentifier{Name: name}}
+		for n := 1; n <= 100; n++ {
+			xp := 0
+			if n > 1 {
+				xp = f(n)
+			}
+			gr.Levels = append(gr.Levels, pokeapi.GrowthRateExperienceLevel{Level: n, Experience: xp})
+		}
+		res[name] = gr
+	}
+	return res
+}
+
+func TestGrowthRate(t *testing.T) {
+	t.Parallel()
+
+	rates := growthRates()
+
+	for name, tc := range map[string]struct {
+		at100      int
+		level, xp  int
+		toNext     int
+		midXP      int
+		levelAtMid int
+	}{
+		pokeapi.GrowthRateErratic:     {at100: 600000, level: 50, xp: 125000, toNext: 6324, midXP: 127000, levelAtMid: 50},
+		pokeapi.GrowthRateFast:        {at100: 800000, level: 10, xp: 800, toNext: 264, midXP: 1000, levelAtMid: 10},
+		pokeapi.GrowthRateMediumFast:  {at100: 1000000, level: 10, xp: 1000, toNext: 331, midXP: 1330, levelAtMid: 10},
+		pokeapi.GrowthRateMediumSlow:  {at100: 1059860, level: 5, xp: 135, toNext: 44, midXP: 178, levelAtMid: 5},
+		pokeapi.GrowthRateSlow:        {at100: 1250000, level: 10, xp: 1250, toNext: 413, midXP: 1663, levelAtMid: 11},
+		pokeapi.GrowthRateFluctuating: {at100: 1640000, level: 50, xp: 142500, toNext: 8722, midXP: 150000, levelAtMid: 50},
+	} {
+		gr := rates[name]
+
+		if got, err := gr.ExperienceForLevel(100); got != tc.at100 || err != nil {
+			t.Errorf("%s: want (%d, nil) at level 100; got (%d, %v)", name, tc.at100, got, err)
+		}
+		if got, err := gr.ExperienceForLevel(tc.level); got != tc.xp || err != nil {
+			t.Errorf("%s: want (%d, nil) at level %d; got (%d, %v)", name, tc.xp, tc.level, got, err)
+		}
+		if got, err := gr.ExperienceToNextLevel(tc.level); got != tc.toNext || err != nil {
+			t.Errorf("%s: want (%d, nil) to level %d; got (%d, %v)", name, tc.toNext, tc.level+1, got, err)
+		}
+		if got := gr.LevelForExperience(tc.midXP); got != tc.levelAtMid {
+			t.Errorf("%s: want level %d at %d experience; got %d", name, tc.levelAtMid, tc.midXP, got)
+		}
+
+		for n := 1; n <= 100; n++ {
+			xp, _ := gr.ExperienceForLevel(n)
+			if got := gr.LevelForExperience(xp); got != n {
+				t.Errorf("%s: want level %d at exactly %d experience; got %d", name, n, xp, got)
+			}
+		}
+		if got, err := gr.ExperienceToNextLevel(100); got != 0 || err != nil {
+			t.Errorf("%s: want (0, nil) at the max level; got (%d, %v)", name, got, err)
+		}
+	}
+
+	if _, err := rates[pokeapi.GrowthRateFast].ExperienceForLevel(101); !errors.Is(err, pokeapi.ErrLevelOutOfRange) {
+		t.Errorf("want ErrLevelOutOfRange for level 101; got %v", err)
+	}
+}
+
+func TestGrowthRate_ExperienceGained(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gr        = growthRates()[pokeapi.GrowthRateMediumSlow]
+		bulbasaur = &pokeapi.Pokemon{BaseExperience: 64}
+	)
+
+	for _, tc := range []struct {
+		name  string
+		level int
+		opts  *pokeapi.ExperienceOpts
+		want  int
+	}{
+		{name: "generation-i wild", level: 10, opts: &pokeapi.ExperienceOpts{Generation: 1}, want: 91},
+		{name: "generation-i split", level: 10, opts: &pokeapi.ExperienceOpts{Generation: 1, Participants: 2}, want: 45},
+		{name: "generation-iv trainer", level: 10, opts: &pokeapi.ExperienceOpts{Generation: 4, Trainer: true}, want: 136},
+		{
+			name:  "generation-iv international lucky egg",
+			level: 10,
+			opts:  &pokeapi.ExperienceOpts{Generation: 4, Traded: true, International: true, LuckyEgg: true},
+			want:  231,
+		},
+		{name: "generation-v same level", level: 10, opts: &pokeapi.ExperienceOpts{Generation: 5, VictorLevel: 10}, want: 129},
+		{name: "generation-v higher victor", level: 10, opts: &pokeapi.ExperienceOpts{Generation: 5, VictorLevel: 30}, want: 36},
+		{name: "generation-vi ignores participants", level: 10, opts: &pokeapi.ExperienceOpts{Generation: 6, Participants: 3}, want: 91},
+		{name: "latest same level", level: 10, opts: &pokeapi.ExperienceOpts{VictorLevel: 10, Trainer: true}, want: 129},
+		{name: "generation-vii trainer", level: 10, opts: &pokeapi.ExperienceOpts{Generation: 7, VictorLevel: 10, Trainer: true}, want: 193},
+		{name: "max level victor", level: 10, opts: &pokeapi.ExperienceOpts{VictorLevel: 100}, want: 0},
+	} {
+		got, err := gr.ExperienceGained(bulbasaur, tc.level, tc.opts)
+		if got != tc.want || err != nil {
+			t.Errorf("%s: want (%d, nil); got (%d, %v)", tc.name, tc.want, got, err)
+		}
+	}
+
+	if _, err := gr.ExperienceGained(bulbasaur, 10, nil); !errors.Is(err, pokeapi.ErrLevelOutOfRange) {
+		t.Errorf("want ErrLevelOutOfRange without a victor level in the latest generation; got %v", err)
+	}
+}
@@ -1,5 +1,10 @@
 package pokeapi
 
+// LatestGeneration refers to whichever Generation is the latest, wherever a
+// Generation is identified by its ID - such as ExperienceOpts.Generation, or the
+// options of the subpackages whose results vary by generation.
+const LatestGeneration = 0
+
 type Generation struct {
 	NamedIdentifier
 
@@ -0,0 +1,223 @@
+// Package games answers questions about the hierarchy of pokeapi.Generation s,
+// pokeapi.VersionGroup s and pokeapi.Version s - such as which generation a
+// game belongs to, or whether one game came before another - without any
+// further calls to PokéAPI once a Registry is built. A Registry can be stored
+// as JSON for use offline.
+//
+// Functions accepting a game accept the name of either a pokeapi.Version (like
+// "heartgold") or a pokeapi.VersionGroup (like "heartgold-soulsilver").
+package games
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// ErrUnknownGame is returned when a game is neither a pokeapi.Version nor a
+// pokeapi.VersionGroup in the Registry.
+var ErrUnknownGame = errors.New("unknown game")
+
+// group is the information the Registry keeps about each pokeapi.VersionGroup.
+// It is the unit of the Registry's JSON representation.
+type group struct {
+	VersionGroup pokeapi.NamedAPIResource[pokeapi.VersionGroup] `json:"version_group"`
+	Order        int                                            `json:"order"`
+	Generation   pokeapi.NamedAPIResource[pokeapi.Generation]   `json:"generation"`
+	Versions     []pokeapi.NamedAPIResource[pokeapi.Version]    `json:"versions"`
+	Regions      []pokeapi.NamedAPIResource[pokeapi.Region]     `json:"regions"`
+	Pokedexes    []pokeapi.NamedAPIResource[pokeapi.Pokedex]    `json:"pokedexes"`
+}
+
+// A Registry holds every pokeapi.VersionGroup, with the pokeapi.Generation it
+// belongs to and the pokeapi.Version s that belong to it. It is safe for
+// concurrent use.
+type Registry struct {
+	groups []group        // ordered by Order.
+	index  map[string]int // version & version group name => index into groups.
+}
+
+// New builds a Registry from the provided version groups. As a
+// pokeapi.VersionGroup does not know its own URL, the references returned by
+// Registry.VersionGroupOf & Registry.VersionGroups only have a name - use Build
+// for complete references.
+func New(vgs []*pokeapi.VersionGroup) *Registry {
+	groups := make([]group, len(vgs))
+	for i, vg := range vgs {
+		groups[i] = group{
+			VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: vg.Name},
+			Order:        vg.Order,
+			Generation:   vg.Generation,
+			Versions:     vg.Versions,
+			Regions:      vg.Regions,
+			Pokedexes:    vg.Pokedexes,
+		}
+	}
+	return newRegistry(groups)
+}
+
+func newRegistry(groups []group) *Registry {
+	groups = slices.Clone(groups)
+	slices.SortStableFunc(groups, func(a, b group) int { return cmp.Compare(a.Order, b.Order) })
+
+	r := Registry{groups: groups, index: make(map[string]int)}
+	for i, g := range groups {
+		r.index[g.VersionGroup.Name] = i
+		for _, v := range g.Versions {
+			r.index[v.Name] = i
+		}
+	}
+	return &r
+}
+
+// Build retrieves every pokeapi.VersionGroup using the provided pokeapi.Client
+// and builds a Registry from them.
+func Build(ctx context.Context, c *pokeapi.Client) (*Registry, error) {
+	refs, err := pokeapi.ListAll(ctx, c, pokeapi.VersionGroupResource, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	vgs, err := pokeapi.GetAll(ctx, c, refs, pokeapi.DefaultConcurrency)
+	if err != nil {
+		return nil, fmt.Errorf("getting version groups: %w", err)
+	}
+
+	byName := make(map[string]pokeapi.NamedAPIResource[pokeapi.VersionGroup], len(refs))
+	for _, ref := range refs {
+		byName[ref.Name] = ref
+	}
+
+	r := New(vgs)
+	for i, g := range r.groups {
+		if ref, ok := byName[g.VersionGroup.Name]; ok {
+			r.groups[i].VersionGroup = ref
+		}
+	}
+	return r, nil
+}
+
+func (r *Registry) lookup(game string) (group, error) {
+	i, ok := r.index[game]
+	if !ok {
+		return group{}, fmt.Errorf("%w: %q", ErrUnknownGame, game)
+	}
+	return r.groups[i], nil
+}
+
+// Compare returns -1 if game a was released before game b, 1 if after, and 0
+// if they belong to the same pokeapi.VersionGroup - as with
+// pokeapi.VersionGroup.Order, similar games are grouped together regardless
+// of release date.
+//
+//	r.Compare("red", "gold") // -1, nil
+func (r *Registry) Compare(a, b string) (int, error) {
+	ga, err := r.lookup(a)
+	if err != nil {
+		return 0, err
+	}
+	gb, err := r.lookup(b)
+	if err != nil {
+		return 0, err
+	}
+	return cmp.Compare(ga.Order, gb.Order), nil
+}
+
+// GenerationOf returns the pokeapi.Generation the game belongs to. Its ID is
+// the generation's number - 1 for generation-i and so on.
+func (r *Registry) GenerationOf(game string) (pokeapi.NamedAPIResource[pokeapi.Generation], error) {
+	g, err := r.lookup(game)
+	if err != nil {
+		return pokeapi.NamedAPIResource[pokeapi.Generation]{}, err
+	}
+	return g.Generation, nil
+}
+
+// VersionGroupOf returns the pokeapi.VersionGroup the game belongs to. For a
+// version group, that is itself.
+func (r *Registry) VersionGroupOf(game string) (pokeapi.NamedAPIResource[pokeapi.VersionGroup], error) {
+	g, err := r.lookup(game)
+	if err != nil {
+		return pokeapi.NamedAPIResource[pokeapi.VersionGroup]{}, err
+	}
+	return g.VersionGroup, nil
+}
+
+// Versions returns the pokeapi.Version s in the pokeapi.VersionGroup the game
+// belongs to.
+func (r *Registry) Versions(game string) ([]pokeapi.NamedAPIResource[pokeapi.Version], error) {
+	g, err := r.lookup(game)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(g.Versions), nil
+}
+
+// Regions returns the pokeapi.Region s that can be visited in the game.
+func (r *Registry) Regions(game string) ([]pokeapi.NamedAPIResource[pokeapi.Region], error) {
+	g, err := r.lookup(game)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(g.Regions), nil
+}
+
+// Pokedexes returns the pokeapi.Pokedex es used by the game.
+func (r *Registry) Pokedexes(game string) ([]pokeapi.NamedAPIResource[pokeapi.Pokedex], error) {
+	g, err := r.lookup(game)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(g.Pokedexes), nil
+}
+
+// Generations returns every pokeapi.Generation with a pokeapi.VersionGroup in
+// the Registry, in order.
+func (r *Registry) Generations() []pokeapi.NamedAPIResource[pokeapi.Generation] {
+	var res []pokeapi.NamedAPIResource[pokeapi.Generation]
+	for _, g := range r.groups {
+		if !slices.ContainsFunc(
+			res,
+			func(gen pokeapi.NamedAPIResource[pokeapi.Generation]) bool { return gen.Name == g.Generation.Name },
+		) {
+			res = append(res, g.Generation)
+		}
+	}
+	slices.SortStableFunc(
+		res,
+		func(a, b pokeapi.NamedAPIResource[pokeapi.Generation]) int { return cmp.Compare(a.ID(), b.ID()) },
+	)
+	return res
+}
+
+// VersionGroups returns every pokeapi.VersionGroup in the Registry, ordered by
+// pokeapi.VersionGroup.Order.
+func (r *Registry) VersionGroups() []pokeapi.NamedAPIResource[pokeapi.VersionGroup] {
+	res := make([]pokeapi.NamedAPIResource[pokeapi.VersionGroup], len(r.groups))
+	for i, g := range r.groups {
+		res[i] = g.VersionGroup
+	}
+	return res
+}
+
+func (r *Registry) MarshalJSON() ([]byte, error) {
+	groups := r.groups
+	if groups == nil {
+		groups = []group{}
+	}
+	return json.Marshal(groups)
+}
+
+func (r *Registry) UnmarshalJSON(b []byte) error {
+	var groups []group
+	if err := json.Unmarshal(b, &groups); err != nil {
+		return err
+	}
+	*r = *newRegistry(groups)
+	return nil
+}
@@ -0,0 +1,173 @@
+package games_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/games"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+func build(t *testing.T) *games.Registry {
+	t.Helper()
+
+	ts, c := pokeapitest.NewServer(t)
+
+	url := func(resource, name string) string { return ts.URL + "/" + resource + "/" + name + "/" }
+	versionGroup := func(name string, order, gen int, region, dex string, versions ...string) pokeapi.VersionGroup {
+		vg := pokeapi.VersionGroup{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+			Order:           order,
+			Generation: pokeapi.NamedAPIResource[pokeapi.Generation]{
+				APIResource: pokeapi.APIResource[pokeapi.Generation]{URL: url("generation", strconv.Itoa(gen))},
+				Name:        []string{"", "generation-i", "generation-ii"}[gen],
+			},
+			Regions: []pokeapi.NamedAPIResource[pokeapi.Region]{
+				{APIResource: pokeapi.APIResource[pokeapi.Region]{URL: url("region", region)}, Name: region},
+			},
+			Pokedexes: []pokeapi.NamedAPIResource[pokeapi.Pokedex]{
+				{APIResource: pokeapi.APIResource[pokeapi.Pokedex]{URL: url("pokedex", dex)}, Name: dex},
+			},
+		}
+		for _, v := range versions {
+			vg.Versions = append(
+				vg.Versions,
+				pokeapi.NamedAPIResource[pokeapi.Version]{
+					APIResource: pokeapi.APIResource[pokeapi.Version]{URL: url("version", v)},
+					Name:        v,
+				},
+			)
+		}
+		return vg
+	}
+
+	p := pokeapi.Page[pokeapi.NamedAPIResource[pokeapi.VersionGroup], pokeapi.VersionGroup]{Count: 3}
+	for _, n := range []string{"gold-silver", "red-blue", "yellow"} {
+		p.Results = append(
+			p.Results,
+			pokeapi.NamedAPIResource[pokeapi.VersionGroup]{
+				APIResource: pokeapi.APIResource[pokeapi.VersionGroup]{URL: url("version-group", n)},
+				Name:        n,
+			},
+		)
+	}
+	ts.Add("/version-group/", p)
+	ts.Add("/version-group/red-blue/", versionGroup("red-blue", 1, 1, "kanto", "kanto", "red", "blue"))
+	ts.Add("/version-group/yellow/", versionGroup("yellow", 2, 1, "kanto", "kanto", "yellow"))
+	ts.Add("/version-group/gold-silver/", versionGroup("gold-silver", 3, 2, "johto", "original-johto", "gold", "silver"))
+
+	r, err := games.Build(context.Background(), c)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	return r
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	r := build(t)
+
+	t.Run(
+		"compares games by release order",
+		func(t *testing.T) {
+			t.Parallel()
+
+			for _, tc := range []struct {
+				a, b string
+				want int
+			}{
+				{a: "red", b: "gold", want: -1},
+				{a: "gold-silver", b: "yellow", want: 1},
+				{a: "red", b: "blue", want: 0},
+				{a: "blue", b: "red-blue", want: 0},
+			} {
+				got, err := r.Compare(tc.a, tc.b)
+				if err != nil || got != tc.want {
+					t.Errorf("%s vs %s: want (%d, nil); got (%d, %v)", tc.a, tc.b, tc.want, got, err)
+				}
+			}
+
+			if _, err := r.Compare("red", "sword"); !errors.Is(err, games.ErrUnknownGame) {
+				t.Errorf("want ErrUnknownGame; got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"looks up the hierarchy of a game",
+		func(t *testing.T) {
+			t.Parallel()
+
+			gen, err := r.GenerationOf("silver")
+			if err != nil || gen.Name != "generation-ii" || gen.ID() != 2 {
+				t.Errorf("want (generation-ii with id 2, nil); got (%+v, %v)", gen, err)
+			}
+
+			vg, err := r.VersionGroupOf("blue")
+			if err != nil || vg.Name != "red-blue" || vg.URL == "" {
+				t.Errorf("want (red-blue with its url, nil); got (%+v, %v)", vg, err)
+			}
+
+			regions, err := r.Regions("gold")
+			if err != nil || len(regions) != 1 || regions[0].Name != "johto" {
+				t.Errorf("want ([johto], nil); got (%+v, %v)", regions, err)
+			}
+
+			dexes, err := r.Pokedexes("yellow")
+			if err != nil || len(dexes) != 1 || dexes[0].Name != "kanto" {
+				t.Errorf("want ([kanto], nil); got (%+v, %v)", dexes, err)
+			}
+
+			versions, err := r.Versions("red")
+			if err != nil || len(versions) != 2 {
+				t.Errorf("want ([red blue], nil); got (%+v, %v)", versions, err)
+			}
+		},
+	)
+
+	t.Run(
+		"lists generations and version groups in order",
+		func(t *testing.T) {
+			t.Parallel()
+
+			gens := r.Generations()
+			if len(gens) != 2 || gens[0].Name != "generation-i" || gens[1].Name != "generation-ii" {
+				t.Errorf("want [generation-i generation-ii]; got %+v", gens)
+			}
+
+			vgs := r.VersionGroups()
+			if len(vgs) != 3 || vgs[0].Name != "red-blue" || vgs[1].Name != "yellow" || vgs[2].Name != "gold-silver" {
+				t.Errorf("want [red-blue yellow gold-silver]; got %+v", vgs)
+			}
+		},
+	)
+
+	t.Run(
+		"round trips through JSON",
+		func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+
+			var got games.Registry
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+
+			if c, err := got.Compare("yellow", "blue"); err != nil || c != 1 {
+				t.Errorf("want (1, nil); got (%d, %v)", c, err)
+			}
+			if vg, err := got.VersionGroupOf("gold"); err != nil || vg.Name != "gold-silver" {
+				t.Errorf("want (gold-silver, nil); got (%+v, %v)", vg, err)
+			}
+		},
+	)
+}
@@ -0,0 +1,147 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// A GetAllError is returned by GetAll when one or more references could not be
+// retrieved. It unwraps to every error that occurred, so errors.Is may be used
+// to check for causes such as ErrNotFound.
+type GetAllError struct {
+	Errs []error // Errs[i] is the error that occurred retrieving refs[i], or nil if it succeeded.
+}
+
+func (e *GetAllError) Error() string {
+	var (
+		failed = e.Unwrap()
+		msg    = fmt.Sprintf("failed to get %d of %d resources", len(failed), len(e.Errs))
+	)
+	if len(failed) == 0 {
+		return msg
+	}
+	return fmt.Sprintf("%s: %v", msg, errors.Join(failed...))
+}
+
+// Unwrap returns the non-nil errors in GetAllError.Errs.
+func (e *GetAllError) Unwrap() []error {
+	var failed []error
+	for _, err := range e.Errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return failed
+}
+
+// DefaultConcurrency is the number of resources retrieved at once by the
+// subpackages that retrieve resources in bulk, unless their options say
+// otherwise.
+const DefaultConcurrency = 4
+
+// GetAll retrieves every resource in refs, fetching at most concurrency at
+// once. A concurrency of less than 1 is treated as 1. Repeated references are
+// only retrieved once.
+//
+// The returned slice is in the same order as refs. If any reference could not
+// be retrieved, its entry is nil and a *GetAllError is returned alongside the
+// resources that could be - one failure does not discard every success.
+//
+//	species, err := pokeapi.GetAll(ctx, c, gen.PokemonSpecies, 8)
+func GetAll[R interface {
+	GettableAPIResource[T]
+	comparable
+}, T any](
+	ctx context.Context,
+	c *Client,
+	refs []R,
+	concurrency int,
+) ([]*T, error) {
+	var (
+		firstIdx = make(map[R]int, len(refs))
+		unique   = make([]int, 0, len(refs)) // indexes into refs of the first occurrence of each reference.
+	)
+	for i, r := range refs {
+		if _, ok := firstIdx[r]; !ok {
+			firstIdx[r] = i
+			unique = append(unique, i)
+		}
+	}
+
+	var (
+		res  = make([]*T, len(refs))
+		errs = make([]error, len(refs))
+	)
+	fanOut(
+		ctx, len(unique), concurrency,
+		func(ctx context.Context, i int) {
+			idx := unique[i]
+			res[idx], errs[idx] = refs[idx].Get(ctx, c)
+		},
+	)
+
+	failed := false
+	for i, r := range refs {
+		first := firstIdx[r]
+		res[i], errs[i] = res[first], errs[first]
+		failed = failed || errs[i] != nil
+	}
+
+	if failed {
+		return res, &GetAllError{Errs: errs}
+	}
+	return res, nil
+}
+
+// listAllPageLimit is the size of the pages ListAll requests, when the
+// ListOpts do not set one.
+const listAllPageLimit = 100
+
+// ListAll lists every resource of the ResourceName, following Page.GetNext
+// until the list is exhausted, and returns their references in list order.
+// ListOpts.Offset skips the first resources; ListOpts.Limit sets the size of
+// each page requested, default 100. Nil ListOpts use the defaults.
+//
+//	refs, err := pokeapi.ListAll(ctx, c, pokeapi.TypeResource, nil)
+//	types, err := pokeapi.GetAll(ctx, c, refs, 4)
+func ListAll[R GettableAPIResource[T], T any](
+	ctx context.Context,
+	c *Client,
+	rn ResourceName[R, T],
+	opts *ListOpts,
+) ([]R, error) {
+	o := ListOpts{Limit: listAllPageLimit}
+	if opts != nil {
+		o.Offset = opts.Offset
+		if opts.Limit > 0 {
+			o.Limit = opts.Limit
+		}
+	}
+
+	var res []R
+	page, err := rn.List(ctx, c, &o)
+	for err == nil {
+		res = append(res, page.Results...)
+		page, err = page.GetNext(ctx, c)
+	}
+	if !errors.Is(err, ErrListExhausted) {
+		return nil, fmt.Errorf("listing %s: %w", rn, err)
+	}
+	return res, nil
+}
+
+// fanOut calls fn for every i in [0, n), running at most concurrency calls at
+// once. A concurrency of less than 1 is treated as 1. It returns once every
+// call has completed.
+func fanOut(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int)) {
+	var g errgroup.Group
+	g.SetLimit(max(concurrency, 1))
+
+	for i := range n {
+		g.Go(func() error { fn(ctx, i); return nil })
+	}
+	_ = g.Wait()
+}
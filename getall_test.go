@@ -0,0 +1,147 @@
+package pokeapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+func TestGetAll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+
+		reqMux sync.Mutex
+		reqs   = make(map[string]int)
+
+		ts = httptest.NewServer(
+			http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					reqMux.Lock()
+					reqs[r.URL.Path]++
+					reqMux.Unlock()
+
+					name := strings.TrimPrefix(r.URL.Path, "/pokemon-species/")
+					if name == "missingno" {
+						http.NotFound(w, r)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(
+						pokeapi.PokemonSpecies{NamedIdentifier: pokeapi.NamedIdentifier{Name: name}},
+					)
+				},
+			),
+		)
+		c = pokeapi.NewClient(&pokeapi.ClientOpts{HTTPClient: ts.Client(), PokeAPIRoot: ts.URL})
+
+		ref = func(name string) pokeapi.NamedAPIResource[pokeapi.PokemonSpecies] {
+			return pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+				APIResource: pokeapi.APIResource[pokeapi.PokemonSpecies]{
+					URL: fmt.Sprintf("%s/pokemon-species/%s", ts.URL, name),
+				},
+				Name: name,
+			}
+		}
+	)
+	t.Cleanup(ts.Close)
+
+	refs := []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+		ref("pichu"), ref("missingno"), ref("pikachu"), ref("pichu"), ref("raichu"),
+	}
+
+	res, err := pokeapi.GetAll(ctx, c, refs, 2)
+
+	var gaErr *pokeapi.GetAllError
+	if !errors.As(err, &gaErr) {
+		t.Fatalf("want a *GetAllError; got %v", err)
+	}
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		t.Errorf("want error to wrap ErrNotFound; got %v", err)
+	}
+
+	for i, r := range refs {
+		if r.Name == "missingno" {
+			if res[i] != nil || gaErr.Errs[i] == nil {
+				t.Errorf("want (nil, error) at index %d; got (%v, %v)", i, res[i], gaErr.Errs[i])
+			}
+			continue
+		}
+
+		if res[i] == nil || res[i].Name != r.Name || gaErr.Errs[i] != nil {
+			t.Errorf("want (%s, nil) at index %d; got (%v, %v)", r.Name, i, res[i], gaErr.Errs[i])
+		}
+	}
+
+	if n := reqs["/pokemon-species/pichu"]; n != 1 {
+		t.Errorf("want repeated references to be fetched once; got %d requests", n)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+
+		names = []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon"}
+		ts    *httptest.Server
+	)
+	ts = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				var opts struct{ limit, offset int }
+				_, _ = fmt.Sscan(r.URL.Query().Get("limit"), &opts.limit)
+				_, _ = fmt.Sscan(r.URL.Query().Get("offset"), &opts.offset)
+				if opts.offset >= len(names) {
+					http.NotFound(w, r)
+					return
+				}
+
+				end := min(opts.offset+opts.limit, len(names))
+				page := pokeapi.Page[pokeapi.NamedAPIResource[pokeapi.Pokemon], pokeapi.Pokemon]{Count: len(names)}
+				if end < len(names) {
+					next := fmt.Sprintf("%s/pokemon/?offset=%d&limit=%d", ts.URL, end, opts.limit)
+					page.Next = &next
+				}
+				for _, n := range names[opts.offset:end] {
+					page.Results = append(page.Results, pokeapi.NamedAPIResource[pokeapi.Pokemon]{Name: n})
+				}
+				_ = json.NewEncoder(w).Encode(page)
+			},
+		),
+	)
+	t.Cleanup(ts.Close)
+	c := pokeapi.NewClient(&pokeapi.ClientOpts{HTTPClient: ts.Client(), PokeAPIRoot: ts.URL})
+
+	for _, tc := range []struct {
+		opts *pokeapi.ListOpts
+		want []string
+	}{
+		{opts: nil, want: names},
+		{opts: &pokeapi.ListOpts{Limit: 2}, want: names},
+		{opts: &pokeapi.ListOpts{Limit: 2, Offset: 3}, want: names[3:]},
+		{opts: &pokeapi.ListOpts{Offset: 10}, want: nil},
+	} {
+		refs, err := pokeapi.ListAll(ctx, c, pokeapi.PokemonResource, tc.opts)
+		if err != nil {
+			t.Fatalf("%+v: want no error; got %v", tc.opts, err)
+		}
+
+		got := make([]string, 0, len(refs))
+		for _, r := range refs {
+			got = append(got, r.Name)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%+v: want %v; got %v", tc.opts, tc.want, got)
+		}
+	}
+}
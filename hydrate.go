@@ -0,0 +1,215 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A Graph holds the resources retrieved by Hydrate, keyed by the URL of the
+// APIResource or NamedAPIResource that refers to them. Values are always
+// pointers to the resource type, so use [APIResource.Lookup] to retrieve them
+// correctly typed.
+type Graph map[string]any
+
+// Lookup retrieves the resource referred to by the APIResource from the Graph,
+// if it was hydrated.
+func (r APIResource[T]) Lookup(g Graph) (*T, bool) {
+	v, ok := g[r.URL].(*T)
+	return v, ok
+}
+
+// HydrateOpts control which references Hydrate follows.
+//
+// Field paths are the dot-separated Go field names leading to a reference,
+// starting from the value passed to Hydrate. Slices and embedded structs do not
+// add to the path, and the fields of a hydrated resource continue the path of
+// the reference it was retrieved from. For example, starting from a Pokemon:
+//
+//	Species                            // the Pokemon's PokemonSpecies
+//	Species.EvolutionChain             // that PokemonSpecies' EvolutionChain
+//	Species.EvolutionChain.Chain.Species
+//	Moves.Move                         // every Move in Pokemon.Moves
+type HydrateOpts struct {
+	// The number of references to follow from the value being hydrated. A depth
+	// of 1 resolves the references held directly by the value, 2 also resolves
+	// the references held by those resources and so on. Default 1.
+	Depth int
+
+	// If any Include paths are set, only references on one of those paths
+	// are followed. Paths leading up to or continuing on from an Include path
+	// are also followed.
+	Include []string
+
+	// References on an Exclude path, or continuing on from one, are never
+	// followed. Exclude takes precedence over Include.
+	Exclude []string
+
+	// The maximum number of resources to retrieve at once. Default 1.
+	Concurrency int
+}
+
+func (o *HydrateOpts) follows(path string) bool {
+	within := func(path, prefix string) bool {
+		return path == prefix || strings.HasPrefix(path, prefix+".")
+	}
+
+	for _, e := range o.Exclude {
+		if within(path, e) {
+			return false
+		}
+	}
+
+	if len(o.Include) == 0 {
+		return true
+	}
+	for _, i := range o.Include {
+		if within(path, i) || within(i, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// hydratable is implemented by APIResource, and therefore NamedAPIResource.
+type hydratable interface {
+	hydrate(ctx context.Context, c *Client) (any, error)
+	resourceURL() string
+}
+
+func (r APIResource[T]) hydrate(ctx context.Context, c *Client) (any, error) { return r.Get(ctx, c) }
+func (r APIResource[T]) resourceURL() string                                 { return r.URL }
+
+var hydratableType = reflect.TypeFor[hydratable]()
+
+// Hydrate walks value, which should be a resource or a pointer to one, and
+// retrieves every APIResource and NamedAPIResource it refers to. It then does
+// the same for each retrieved resource, until HydrateOpts.Depth is reached.
+//
+// Every resource is retrieved through the Client (and so its Cache) at most
+// once, even if it is referred to many times. Resources at the same depth are
+// retrieved concurrently. If a resource is referred to on more than one path,
+// only the first path found is used to decide which of its references to
+// follow.
+//
+// If some resources could not be retrieved, the Graph of those that could is
+// returned alongside the errors that occurred.
+//
+//	g, err := pokeapi.Hydrate(ctx, c, pokemon, &pokeapi.HydrateOpts{Depth: 2, Exclude: []string{"Moves"}})
+//	species, _ := pokemon.Species.Lookup(g)
+//	chain, _ := species.EvolutionChain.Lookup(g)
+func Hydrate(ctx context.Context, c *Client, value any, opts *HydrateOpts) (Graph, error) {
+	if opts == nil {
+		opts = &HydrateOpts{}
+	}
+	depth := opts.Depth
+	if depth == 0 {
+		depth = 1
+	}
+
+	type node struct {
+		value any
+		path  string
+	}
+
+	var (
+		g        = make(Graph)
+		errs     []error
+		frontier = []node{{value: value}}
+	)
+
+	for d := 0; d < depth && len(frontier) != 0; d++ {
+		var (
+			refs  []hydratable
+			paths []string
+			seen  = make(map[string]bool)
+		)
+		for _, n := range frontier {
+			collectRefs(
+				reflect.ValueOf(n.value), n.path, opts,
+				func(r hydratable, path string) {
+					u := r.resourceURL()
+					if _, ok := g[u]; ok || seen[u] || u == "" {
+						return
+					}
+					seen[u] = true
+					refs, paths = append(refs, r), append(paths, path)
+				},
+			)
+		}
+
+		var (
+			res     = make([]any, len(refs))
+			resErrs = make([]error, len(refs))
+		)
+		fanOut(
+			ctx, len(refs), opts.Concurrency,
+			func(ctx context.Context, i int) { res[i], resErrs[i] = refs[i].hydrate(ctx, c) },
+		)
+
+		frontier = frontier[:0]
+		for i, r := range refs {
+			if resErrs[i] != nil {
+				errs = append(
+					errs,
+					fmt.Errorf("hydrating %s (%s): %w", paths[i], r.resourceURL(), resErrs[i]),
+				)
+				continue
+			}
+
+			g[r.resourceURL()] = res[i]
+			frontier = append(frontier, node{value: res[i], path: paths[i]})
+		}
+	}
+
+	return g, errors.Join(errs...)
+}
+
+// collectRefs calls found for every hydratable reachable from v whose path is
+// followed.
+func collectRefs(v reflect.Value, path string, opts *HydrateOpts, found func(hydratable, string)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectRefs(v.Elem(), path, opts, found)
+		}
+
+	case reflect.Struct:
+		if v.Type().Implements(hydratableType) {
+			found(v.Interface().(hydratable), path)
+			return
+		}
+
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			fieldPath := path
+			if !f.Anonymous {
+				fieldPath = joinPath(path, f.Name)
+				if !opts.follows(fieldPath) {
+					continue
+				}
+			}
+			collectRefs(v.Field(i), fieldPath, opts, found)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			collectRefs(v.Index(i), path, opts, found)
+		}
+
+	default:
+	}
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
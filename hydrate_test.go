@@ -0,0 +1,144 @@
+package pokeapi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+func namedRef[T any](rs *pokeapitest.Server, path, name string) pokeapi.NamedAPIResource[T] {
+	return pokeapi.NamedAPIResource[T]{
+		APIResource: pokeapi.APIResource[T]{URL: rs.URL + path},
+		Name:        name,
+	}
+}
+
+func TestHydrate(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*pokeapitest.Server, *pokeapi.Client, *pokeapi.Pokemon) {
+		t.Helper()
+
+		rs, c := pokeapitest.NewServer(t)
+
+		electric := namedRef[pokeapi.Type](rs, "/type/13/", "electric")
+		rs.Add("/type/13/", pokeapi.Type{NamedIdentifier: pokeapi.NamedIdentifier{Name: "electric"}})
+		rs.Add(
+			"/pokemon-species/25/",
+			pokeapi.PokemonSpecies{
+				NamedIdentifier: pokeapi.NamedIdentifier{Name: "pikachu"},
+				EvolutionChain:  pokeapi.APIResource[pokeapi.EvolutionChain]{URL: rs.URL + "/evolution-chain/10/"},
+			},
+		)
+		rs.Add(
+			"/evolution-chain/10/",
+			pokeapi.EvolutionChain{Identifier: pokeapi.Identifier{ID: 10}},
+		)
+
+		return rs, c, &pokeapi.Pokemon{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: "pikachu"},
+			Species:         namedRef[pokeapi.PokemonSpecies](rs, "/pokemon-species/25/", "pikachu"),
+			Types:           []pokeapi.PokemonType{{Slot: 1, Type: electric}},
+			PastTypes: []pokeapi.PokemonTypePast{
+				{Types: []pokeapi.PokemonType{{Slot: 1, Type: electric}}},
+			},
+		}
+	}
+
+	t.Run(
+		"resolves direct references once each by default",
+		func(t *testing.T) {
+			t.Parallel()
+
+			rs, c, p := setup(t)
+
+			g, err := pokeapi.Hydrate(context.Background(), c, p, nil)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+
+			if s, ok := p.Species.Lookup(g); !ok || s.Name != "pikachu" {
+				t.Errorf("want species pikachu to be hydrated; got (%v, %t)", s, ok)
+			}
+			if ty, ok := p.Types[0].Type.Lookup(g); !ok || ty.Name != "electric" {
+				t.Errorf("want type electric to be hydrated; got (%v, %t)", ty, ok)
+			}
+			if n := rs.Requests("/type/13/"); n != 1 {
+				t.Errorf("want repeated references to be fetched once; got %d requests", n)
+			}
+			if n := rs.Requests("/evolution-chain/10/"); n != 0 {
+				t.Errorf("want evolution chain not to be fetched at depth 1; got %d requests", n)
+			}
+		},
+	)
+
+	t.Run(
+		"follows references to the requested depth",
+		func(t *testing.T) {
+			t.Parallel()
+
+			_, c, p := setup(t)
+
+			g, err := pokeapi.Hydrate(context.Background(), c, p, &pokeapi.HydrateOpts{Depth: 2, Concurrency: 4})
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+
+			s, _ := p.Species.Lookup(g)
+			if chain, ok := s.EvolutionChain.Lookup(g); !ok || chain.ID != 10 {
+				t.Errorf("want evolution chain 10 to be hydrated; got (%v, %t)", chain, ok)
+			}
+		},
+	)
+
+	t.Run(
+		"only follows included paths that are not excluded",
+		func(t *testing.T) {
+			t.Parallel()
+
+			rs, c, p := setup(t)
+
+			g, err := pokeapi.Hydrate(
+				context.Background(), c, p,
+				&pokeapi.HydrateOpts{
+					Depth:   2,
+					Include: []string{"Species.EvolutionChain", "Types"},
+					Exclude: []string{"Types.Type"},
+				},
+			)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+
+			if len(g) != 2 {
+				t.Errorf("want species & evolution chain to be hydrated; got %v", g)
+			}
+			if n := rs.Requests("/type/13/"); n != 0 {
+				t.Errorf("want excluded type not to be fetched; got %d requests", n)
+			}
+		},
+	)
+
+	t.Run(
+		"returns the partial graph alongside any errors",
+		func(t *testing.T) {
+			t.Parallel()
+
+			rs, c, p := setup(t)
+			p.Abilities = []pokeapi.PokemonAbility{
+				{Ability: namedRef[pokeapi.Ability](rs, "/ability/9/", "static")},
+			}
+
+			g, err := pokeapi.Hydrate(context.Background(), c, p, nil)
+			if !errors.Is(err, pokeapi.ErrNotFound) {
+				t.Errorf("want error to wrap ErrNotFound; got %v", err)
+			}
+			if _, ok := p.Species.Lookup(g); !ok {
+				t.Errorf("want species to be hydrated despite the error")
+			}
+		},
+	)
+}
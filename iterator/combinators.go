@@ -0,0 +1,158 @@
+//go:build go1.23
+
+package iterator
+
+import (
+	"iter"
+)
+
+// The combinators in this file operate on the iter.Seq2[*T, error] streams
+// returned by Seq. Errors are always passed through untouched - they are never
+// filtered, mapped, skipped or counted towards a limit. Context cancellation is
+// handled by the source sequence, which yields the context's error and ends.
+
+// Filter returns a sequence that yields only the resources for which keep
+// returns true.
+func Filter[T any](seq iter.Seq2[*T, error], keep func(*T) bool) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for v, err := range seq {
+			if err == nil && !keep(v) {
+				continue
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns a sequence that yields the result of calling fn on each resource.
+// If fn returns an error, it is yielded in place of the mapped value.
+func Map[T, U any](seq iter.Seq2[*T, error], fn func(*T) (*U, error)) iter.Seq2[*U, error] {
+	return func(yield func(*U, error) bool) {
+		for v, err := range seq {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Take returns a sequence that ends after n resources have been yielded. No
+// further resources are pulled from seq once the limit is reached.
+func Take[T any](seq iter.Seq2[*T, error], n int) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		if n <= 0 {
+			return
+		}
+
+		taken := 0
+		for v, err := range seq {
+			if !yield(v, err) {
+				return
+			}
+			if err != nil {
+				continue
+			}
+
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence that discards the first n resources of seq.
+func Skip[T any](seq iter.Seq2[*T, error], n int) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		skipped := 0
+		for v, err := range seq {
+			if err == nil && skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// Batch returns a sequence that groups resources into slices of length n. The
+// final batch may be shorter. If seq yields an error, any partially filled
+// batch is yielded first, followed by the error.
+func Batch[T any](seq iter.Seq2[*T, error], n int) iter.Seq2[[]*T, error] {
+	if n < 1 {
+		n = 1
+	}
+
+	return func(yield func([]*T, error) bool) {
+		batch := make([]*T, 0, n)
+
+		for v, err := range seq {
+			if err != nil {
+				if len(batch) != 0 {
+					if !yield(batch, nil) {
+						return
+					}
+					batch = make([]*T, 0, n)
+				}
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+
+			batch = append(batch, v)
+			if len(batch) == n {
+				if !yield(batch, nil) {
+					return
+				}
+				batch = make([]*T, 0, n)
+			}
+		}
+
+		if len(batch) != 0 {
+			yield(batch, nil)
+		}
+	}
+}
+
+// Collect gathers every resource in seq into a slice. Collection stops at the
+// first error, which is returned alongside the resources gathered before it.
+func Collect[T any](seq iter.Seq2[*T, error]) ([]*T, error) {
+	var res []*T
+	for v, err := range seq {
+		if err != nil {
+			return res, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+// CollectMap gathers every resource in seq into a map, keyed by the result of
+// calling keyFn on each resource. Later resources overwrite earlier ones with
+// the same key. Collection stops at the first error, which is returned
+// alongside the resources gathered before it.
+func CollectMap[K comparable, T any](
+	seq iter.Seq2[*T, error],
+	keyFn func(*T) K,
+) (map[K]*T, error) {
+	res := make(map[K]*T)
+	for v, err := range seq {
+		if err != nil {
+			return res, err
+		}
+		res[keyFn(v)] = v
+	}
+	return res, nil
+}
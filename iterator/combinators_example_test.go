@@ -0,0 +1,81 @@
+//go:build go1.23
+
+package iterator_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/cache"
+	"github.com/nightmarlin/pokeapi/iterator"
+)
+
+func ExampleFilter() {
+	var (
+		ctx, cancel = signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+		c           = pokeapi.NewClient(&pokeapi.ClientOpts{Cache: cache.NewLRU(nil)})
+	)
+	defer cancel()
+
+	legendaries, err := iterator.Collect(
+		iterator.Filter(
+			iterator.Seq(ctx, c, pokeapi.PokemonSpeciesResource),
+			func(s *pokeapi.PokemonSpecies) bool { return s.IsLegendary },
+		),
+	)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to find all the legendary species: %v", err)
+		return
+	}
+
+	for _, s := range legendaries {
+		fmt.Println(s.Name, "is legendary!")
+	}
+}
+
+func ExampleCollectMap() {
+	var (
+		ctx, cancel = signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+		c           = pokeapi.NewClient(&pokeapi.ClientOpts{Cache: cache.NewLRU(nil)})
+	)
+	defer cancel()
+
+	powerful, err := iterator.CollectMap(
+		iterator.Filter(
+			iterator.Seq(ctx, c, pokeapi.MoveResource),
+			func(m *pokeapi.Move) bool { return m.Power != nil && *m.Power > 100 },
+		),
+		func(m *pokeapi.Move) string { return m.Name },
+	)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to find all the powerful moves: %v", err)
+		return
+	}
+
+	for name, m := range powerful {
+		fmt.Println(name, "has", *m.Power, "power")
+	}
+}
+
+func ExampleBatch() {
+	var (
+		ctx, cancel = signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+		c           = pokeapi.NewClient(&pokeapi.ClientOpts{Cache: cache.NewLRU(nil)})
+	)
+	defer cancel()
+
+	for batch, err := range iterator.Batch(
+		iterator.Take(iterator.Seq(ctx, c, pokeapi.BerryResource), 20),
+		6,
+	) {
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "failed to get the next batch of berries: %v", err)
+			return
+		}
+
+		fmt.Println("here come", len(batch), "more berries!")
+	}
+}
@@ -0,0 +1,273 @@
+//go:build go1.23
+
+package iterator_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/iterator"
+)
+
+// item is either a value or an error, for building test sequences.
+type item struct {
+	v   int
+	err error
+}
+
+// seqOf returns a sequence that yields each item in turn, and a function that
+// reports how many items have been pulled from the sequence so far.
+func seqOf(items ...item) (_ iter.Seq2[*int, error], pulled func() int) {
+	n := 0
+	return func(yield func(*int, error) bool) {
+		for _, it := range items {
+			n++
+			if it.err != nil {
+				if !yield(nil, it.err) {
+					return
+				}
+				continue
+			}
+			if !yield(ptr(it.v), nil) {
+				return
+			}
+		}
+	}, func() int { return n }
+}
+
+func values(vs ...int) []item {
+	res := make([]item, len(vs))
+	for i, v := range vs {
+		res[i] = item{v: v}
+	}
+	return res
+}
+
+// drain collects every value & error yielded by seq, without stopping at errors.
+func drain[T any](seq iter.Seq2[T, error]) (vs []T, errs []error) {
+	for v, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		vs = append(vs, v)
+	}
+	return vs, errs
+}
+
+func deref(vs []*int) []int {
+	res := make([]int, len(vs))
+	for i, v := range vs {
+		res[i] = *v
+	}
+	return res
+}
+
+func TestCombinators(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+
+	t.Run(
+		"Filter keeps matching values and passes errors through",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, _ := seqOf(item{v: 1}, item{v: 2}, item{err: errBoom}, item{v: 3}, item{v: 4})
+
+			vs, errs := drain(iterator.Filter(seq, func(i *int) bool { return *i%2 == 0 }))
+			if got, want := deref(vs), []int{2, 4}; !slices.Equal(got, want) {
+				t.Errorf("want values %v; got %v", want, got)
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], errBoom) {
+				t.Errorf("want errors [%v]; got %v", errBoom, errs)
+			}
+		},
+	)
+
+	t.Run(
+		"Map transforms values and yields mapping errors",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, _ := seqOf(values(1, 2, 3)...)
+
+			vs, errs := drain(
+				iterator.Map(
+					seq,
+					func(i *int) (*string, error) {
+						if *i == 2 {
+							return nil, errBoom
+						}
+						return ptr(string(rune('a' + *i))), nil
+					},
+				),
+			)
+
+			got := make([]string, len(vs))
+			for i, v := range vs {
+				got[i] = *v
+			}
+			if want := []string{"b", "d"}; !slices.Equal(got, want) {
+				t.Errorf("want values %v; got %v", want, got)
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], errBoom) {
+				t.Errorf("want errors [%v]; got %v", errBoom, errs)
+			}
+		},
+	)
+
+	t.Run(
+		"Take stops pulling from the source once the limit is reached",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, pulled := seqOf(values(1, 2, 3, 4, 5)...)
+
+			vs, errs := drain(iterator.Take(seq, 2))
+			if got, want := deref(vs), []int{1, 2}; !slices.Equal(got, want) {
+				t.Errorf("want values %v; got %v", want, got)
+			}
+			if len(errs) != 0 {
+				t.Errorf("want no errors; got %v", errs)
+			}
+			if got := pulled(); got != 2 {
+				t.Errorf("want 2 values pulled from the source; got %d", got)
+			}
+		},
+	)
+
+	t.Run(
+		"Take does not count errors towards the limit",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, _ := seqOf(item{err: errBoom}, item{v: 1}, item{v: 2})
+
+			vs, errs := drain(iterator.Take(seq, 2))
+			if got, want := deref(vs), []int{1, 2}; !slices.Equal(got, want) {
+				t.Errorf("want values %v; got %v", want, got)
+			}
+			if len(errs) != 1 {
+				t.Errorf("want 1 error; got %v", errs)
+			}
+		},
+	)
+
+	t.Run(
+		"Skip discards the first n values",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, _ := seqOf(values(1, 2, 3, 4)...)
+
+			vs, _ := drain(iterator.Skip(seq, 3))
+			if got, want := deref(vs), []int{4}; !slices.Equal(got, want) {
+				t.Errorf("want values %v; got %v", want, got)
+			}
+		},
+	)
+
+	t.Run(
+		"Batch groups values and flushes a partial batch before an error",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, _ := seqOf(item{v: 1}, item{v: 2}, item{v: 3}, item{err: errBoom}, item{v: 4})
+
+			var got [][]int
+			var errs []error
+			for b, err := range iterator.Batch(seq, 2) {
+				if err != nil {
+					errs = append(errs, err)
+					got = append(got, nil)
+					continue
+				}
+				got = append(got, deref(b))
+			}
+
+			want := [][]int{{1, 2}, {3}, nil, {4}}
+			if !slices.EqualFunc(got, want, slices.Equal) {
+				t.Errorf("want batches %v; got %v", want, got)
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], errBoom) {
+				t.Errorf("want errors [%v]; got %v", errBoom, errs)
+			}
+		},
+	)
+
+	t.Run(
+		"Collect stops at the first error",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, pulled := seqOf(item{v: 1}, item{err: errBoom}, item{v: 2})
+
+			vs, err := iterator.Collect(seq)
+			if !errors.Is(err, errBoom) {
+				t.Errorf("want error %v; got %v", errBoom, err)
+			}
+			if got, want := deref(vs), []int{1}; !slices.Equal(got, want) {
+				t.Errorf("want values %v; got %v", want, got)
+			}
+			if got := pulled(); got != 2 {
+				t.Errorf("want 2 items pulled from the source; got %d", got)
+			}
+		},
+	)
+
+	t.Run(
+		"CollectMap keys values by keyFn",
+		func(t *testing.T) {
+			t.Parallel()
+
+			seq, _ := seqOf(values(1, 2, 3)...)
+
+			m, err := iterator.CollectMap(seq, func(i *int) bool { return *i%2 == 0 })
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if len(m) != 2 || *m[true] != 2 || *m[false] != 3 {
+				t.Errorf("want map[false:3 true:2]; got %v", m)
+			}
+		},
+	)
+}
+
+func TestSeq(t *testing.T) {
+	t.Parallel()
+
+	t.Run(
+		"yields the context error and stops when the context is cancelled",
+		func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ts = httptest.NewServer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, _ *http.Request) {
+							t.Error("want no requests to be made with a cancelled context")
+							http.NotFound(w, nil)
+						},
+					),
+				)
+				c = pokeapi.NewClient(&pokeapi.ClientOpts{HTTPClient: ts.Client(), PokeAPIRoot: ts.URL})
+
+				ctx, cancel = context.WithCancel(context.Background())
+			)
+			t.Cleanup(ts.Close)
+			cancel()
+
+			vs, err := iterator.Collect(iterator.Seq(ctx, c, pokeapi.PokemonResource))
+			if !errors.Is(err, context.Canceled) || len(vs) != 0 {
+				t.Errorf("want ([], context.Canceled); got (%v, %v)", vs, err)
+			}
+		},
+	)
+}
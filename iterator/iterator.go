@@ -12,21 +12,33 @@ import (
 	"github.com/nightmarlin/pokeapi"
 )
 
-type nextPageFn[R pokeapi.GettableAPIResource[T], T any] func(
+// ErrOffsetOutOfRange is returned by Iterator.Seek when the requested offset
+// lies outside the list being iterated.
+var ErrOffsetOutOfRange = errors.New("offset out of range")
+
+type listFn[R pokeapi.GettableAPIResource[T], T any] func(
 	context.Context,
 	*pokeapi.Client,
+	*pokeapi.ListOpts,
 ) (*pokeapi.Page[R, T], error)
 
 // An Iterator allows for quick and easy iteration through the elements of every
 // pokeapi.Page of a resource list.
+//
+// The Iterator acts as a cursor positioned between elements of the list: Next
+// returns the element after the cursor and advances it, Prev returns the
+// element before the cursor and moves it back. Calling Prev after Next
+// therefore returns the same element twice.
 type Iterator[R pokeapi.GettableAPIResource[T], T any] struct {
 	mux sync.Mutex
 
 	client *pokeapi.Client
 
-	results     []R
-	nextReadIDX int
-	nextPageFn  nextPageFn[R, T]
+	page       *pokeapi.Page[R, T] // the page containing the cursor, or nil if none has been fetched yet.
+	pageOffset int                 // the offset of the first element of page within the list.
+	pos        int                 // the offset of the cursor within the list.
+
+	listFn listFn[R, T] // fetches the first page at a given offset. nil once the Iterator is stopped.
 
 	closeOnce sync.Once
 }
@@ -39,12 +51,7 @@ func New[R pokeapi.GettableAPIResource[T], T any](
 	client *pokeapi.Client,
 	resourceName pokeapi.ResourceName[R, T],
 ) *Iterator[R, T] {
-	return &Iterator[R, T]{
-		client: client,
-		nextPageFn: func(ctx context.Context, c *pokeapi.Client) (*pokeapi.Page[R, T], error) {
-			return resourceName.List(ctx, c, nil)
-		},
-	}
+	return &Iterator[R, T]{client: client, listFn: resourceName.List}
 }
 
 // NewFromPage creates a new Iterator that starts at the provided pokeapi.Page.
@@ -56,62 +63,199 @@ func NewFromPage[R pokeapi.GettableAPIResource[T], T any](
 	client *pokeapi.Client,
 	page pokeapi.Page[R, T],
 ) *Iterator[R, T] {
-	return &Iterator[R, T]{
-		client:     client,
-		results:    page.Results,
-		nextPageFn: page.GetNext,
+	i := &Iterator[R, T]{
+		client: client,
+		listFn: func(ctx context.Context, c *pokeapi.Client, opts *pokeapi.ListOpts) (*pokeapi.Page[R, T], error) {
+			return page.GetOffset(ctx, c, opts.Offset)
+		},
 	}
+	i.setPage(&page)
+	i.pos = i.pageOffset
+	return i
+}
+
+func (i *Iterator[R, T]) setPage(p *pokeapi.Page[R, T]) {
+	i.page = p
+	i.pageOffset = p.Offset()
+}
+
+// inPage reports whether the element at offset is held by the current page.
+func (i *Iterator[R, T]) inPage(offset int) bool {
+	return i.page != nil && offset >= i.pageOffset && offset < i.pageOffset+len(i.page.Results)
+}
+
+// load ensures the current page holds the element at offset, fetching the
+// adjacent page if the offset lies just beyond it, or the page starting at the
+// offset otherwise. It must be called with i.mux held.
+func (i *Iterator[R, T]) load(ctx context.Context, offset int) error {
+	if i.listFn == nil {
+		return pokeapi.ErrListExhausted
+	}
+	if i.inPage(offset) {
+		return nil
+	}
+	if offset < 0 || (i.page != nil && offset >= i.page.Count) {
+		return pokeapi.ErrListExhausted
+	}
+
+	var (
+		p   *pokeapi.Page[R, T]
+		err error
+	)
+	switch {
+	case i.page == nil:
+		p, err = i.listFn(ctx, i.client, &pokeapi.ListOpts{Offset: offset})
+	case offset == i.pageOffset+len(i.page.Results):
+		p, err = i.page.GetNext(ctx, i.client)
+	case offset == i.pageOffset-1:
+		p, err = i.page.GetPrevious(ctx, i.client)
+	default:
+		p, err = i.page.GetOffset(ctx, i.client, offset)
+	}
+	if err != nil {
+		return err
+	}
+
+	i.setPage(p)
+	if !i.inPage(offset) {
+		return pokeapi.ErrListExhausted
+	}
+	return nil
+}
+
+// get fetches the resource at offset, which is the "next" or "previous"
+// resource. It must be called with i.mux held.
+func (i *Iterator[R, T]) get(ctx context.Context, offset int, which string) (*T, error) {
+	if err := i.load(ctx, offset); err != nil {
+		if errors.Is(err, pokeapi.ErrListExhausted) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("fetching %s page: %w", which, err)
+	}
+
+	r, err := i.page.Results[offset-i.pageOffset].Get(ctx, i.client)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s resource: %w", which, err)
+	}
+	return r, nil
 }
 
 // Next fetches the next page if the current one is empty or has been exhausted.
 // It then fetches the next available pokeapi.GettableAPIResource and returns
 // its value (or any error that may have occurred).
 //
-// Calling Next after Stop will return pokeapi.ErrListExhausted.
+// Once the list is exhausted the Iterator is stopped, and calling Next (or any
+// other method) again will return pokeapi.ErrListExhausted. Calling Next after
+// Stop will also return pokeapi.ErrListExhausted.
 func (i *Iterator[R, T]) Next(ctx context.Context) (*T, error) {
 	defer i.mux.Unlock()
 	i.mux.Lock()
 
-	if i.nextPageFn == nil {
-		return nil, pokeapi.ErrListExhausted
+	r, err := i.get(ctx, i.pos, "next")
+	if err != nil {
+		if errors.Is(err, pokeapi.ErrListExhausted) {
+			i.close()
+		}
+		return nil, err
 	}
+	i.pos += 1
+	return r, nil
+}
 
-	if len(i.results) <= i.nextReadIDX {
-		// fetch next page
-
-		np, err := i.nextPageFn(ctx, i.client)
-		if err != nil {
-			if errors.Is(err, pokeapi.ErrListExhausted) {
-				i.close()
-				return nil, pokeapi.ErrListExhausted
-			}
-			return nil, fmt.Errorf("fetching next page: %w", err)
-		}
+// Prev fetches the previous page if the cursor is at the start of the current
+// one, using pokeapi.Page.Previous. It then fetches the preceding
+// pokeapi.GettableAPIResource and returns its value (or any error that may have
+// occurred).
+//
+// Calling Prev at the start of the list, once Next has exhausted the list, or
+// after Stop, will return pokeapi.ErrListExhausted.
+func (i *Iterator[R, T]) Prev(ctx context.Context) (*T, error) {
+	defer i.mux.Unlock()
+	i.mux.Lock()
 
-		i.nextPageFn = np.GetNext
-		i.results = np.Results
-		i.nextReadIDX = 0
+	r, err := i.get(ctx, i.pos-1, "previous")
+	if err != nil {
+		return nil, err
 	}
+	i.pos -= 1
+	return r, nil
+}
+
+// Peek returns the resource that the next call to Next would return, without
+// moving the cursor.
+func (i *Iterator[R, T]) Peek(ctx context.Context) (*T, error) {
+	defer i.mux.Unlock()
+	i.mux.Lock()
+
+	return i.get(ctx, i.pos, "next")
+}
+
+// Seek moves the cursor to offset, so that the next call to Next returns the
+// resource at that position in the list. Seeking to Len positions the cursor at
+// the end of the list. If the offset lies outside the current page, the page
+// containing it is fetched directly using pokeapi.ListOpts - the list is not
+// re-walked.
+//
+// ErrOffsetOutOfRange is returned if the offset lies outside the list.
+func (i *Iterator[R, T]) Seek(ctx context.Context, offset int) error {
+	defer i.mux.Unlock()
+	i.mux.Lock()
 
-	if len(i.results) == 0 {
-		i.close()
-		return nil, pokeapi.ErrListExhausted
+	if i.listFn == nil {
+		return pokeapi.ErrListExhausted
 	}
 
-	r, err := i.results[i.nextReadIDX].Get(ctx, i.client)
+	n, err := i.len(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("fetching next resource: %w", err)
+		return err
 	}
-	i.nextReadIDX += 1
-	return r, nil
+	if offset < 0 || offset > n {
+		return fmt.Errorf("seeking to %d in a list of %d: %w", offset, n, ErrOffsetOutOfRange)
+	}
+
+	if offset < n {
+		if err := i.load(ctx, offset); err != nil {
+			return fmt.Errorf("seeking to %d: %w", offset, err)
+		}
+	}
+	i.pos = offset
+	return nil
+}
+
+// Len returns the total number of resources in the list, as reported by
+// pokeapi.Page.Count. If no page has been fetched yet, the first page is
+// fetched to find out.
+func (i *Iterator[R, T]) Len(ctx context.Context) (int, error) {
+	defer i.mux.Unlock()
+	i.mux.Lock()
+
+	return i.len(ctx)
+}
+
+// len implements Len. It must be called with i.mux held.
+func (i *Iterator[R, T]) len(ctx context.Context) (int, error) {
+	if i.listFn == nil {
+		return 0, pokeapi.ErrListExhausted
+	}
+
+	if i.page == nil {
+		if err := i.load(ctx, i.pos); err != nil {
+			if errors.Is(err, pokeapi.ErrListExhausted) {
+				return 0, nil
+			}
+			return 0, fmt.Errorf("fetching first page: %w", err)
+		}
+	}
+	return i.page.Count, nil
 }
 
 func (i *Iterator[R, T]) close() {
 	i.closeOnce.Do(
 		func() {
-			i.nextPageFn = nil
-			i.results = nil
-			i.nextReadIDX = 0
+			i.listFn = nil
+			i.page = nil
+			i.pageOffset = 0
+			i.pos = 0
 			i.client = nil
 		},
 	)
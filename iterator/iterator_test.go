@@ -294,4 +294,139 @@ func TestIterator(t *testing.T) {
 			}
 		},
 	)
+
+	t.Run(
+		"supports moving backwards, peeking and seeking across pages",
+		func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ctx          = context.Background()
+				handler, add = stub(t)
+				ts           = httptest.NewServer(handler)
+				c            = pokeapi.NewClient(
+					&pokeapi.ClientOpts{HTTPClient: ts.Client(), PokeAPIRoot: ts.URL},
+				)
+				i = iterator.New(c, pokeapi.PokemonResource)
+
+				names = []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon"}
+				page  = func(offset int, next, prev *string) any {
+					p := pokeapi.Page[pokeapi.NamedAPIResource[pokeapi.Pokemon], pokeapi.Pokemon]{
+						Count:    len(names),
+						Next:     next,
+						Previous: prev,
+					}
+					for id := offset + 1; id <= min(offset+2, len(names)); id++ {
+						p.Results = append(
+							p.Results,
+							pokeapi.NamedAPIResource[pokeapi.Pokemon]{
+								APIResource: pokeapi.APIResource[pokeapi.Pokemon]{
+									URL: fmt.Sprintf("%s/pokemon/%d", ts.URL, id),
+								},
+								Name: names[id-1],
+							},
+						)
+					}
+					return p
+				}
+			)
+			t.Cleanup(ts.Close)
+			t.Cleanup(i.Stop)
+
+			// page links follow PokéAPI's conventions: the offset is omitted for the
+			// first page, and the previous link for the second page is to offset 0.
+			first := page(0, ptr(fmt.Sprintf("%s/pokemon/?limit=2&offset=2", ts.URL)), nil)
+			add("/pokemon/", first)
+			add("/pokemon/?limit=2", first)
+			add(
+				"/pokemon/?limit=2&offset=2",
+				page(
+					2,
+					ptr(fmt.Sprintf("%s/pokemon/?limit=2&offset=4", ts.URL)),
+					ptr(fmt.Sprintf("%s/pokemon/?limit=2", ts.URL)),
+				),
+			)
+			add(
+				"/pokemon/?limit=2&offset=4",
+				page(4, nil, ptr(fmt.Sprintf("%s/pokemon/?limit=2&offset=2", ts.URL))),
+			)
+			for id, name := range names {
+				add(
+					fmt.Sprintf("/pokemon/%d", id+1),
+					pokeapi.Pokemon{
+						NamedIdentifier: pokeapi.NamedIdentifier{
+							Identifier: pokeapi.Identifier{ID: id + 1},
+							Name:       name,
+						},
+					},
+				)
+			}
+
+			wantID := func(op string, v *pokeapi.Pokemon, err error, id int) {
+				t.Helper()
+				if v == nil || v.ID != id || err != nil {
+					t.Errorf("want %s to return (ID:%d, nil); got (%v, %v)", op, id, v, err)
+				}
+			}
+
+			n, err := i.Len(ctx)
+			if n != len(names) || err != nil {
+				t.Errorf("want Len to return (%d, nil); got (%d, %v)", len(names), n, err)
+			}
+
+			v, err := i.Prev(ctx)
+			if !errors.Is(err, pokeapi.ErrListExhausted) || v != nil {
+				t.Errorf("want Prev at the start of the list to return (nil, ErrListExhausted); got (%v, %v)", v, err)
+			}
+
+			v, err = i.Peek(ctx)
+			wantID("Peek", v, err, 1)
+			v, err = i.Next(ctx)
+			wantID("Next", v, err, 1)
+			v, err = i.Next(ctx)
+			wantID("Next", v, err, 2)
+			v, err = i.Next(ctx)
+			wantID("Next onto the second page", v, err, 3)
+
+			v, err = i.Prev(ctx)
+			wantID("Prev after Next", v, err, 3)
+			v, err = i.Prev(ctx)
+			wantID("Prev back onto the first page", v, err, 2)
+
+			if err := i.Seek(ctx, 0); err != nil {
+				t.Errorf("want Seek(0) to succeed; got %v", err)
+			}
+			v, err = i.Next(ctx)
+			wantID("Next after Seek(0)", v, err, 1)
+
+			if err := i.Seek(ctx, 6); !errors.Is(err, iterator.ErrOffsetOutOfRange) {
+				t.Errorf("want Seek(6) to return ErrOffsetOutOfRange; got %v", err)
+			}
+			v, err = i.Peek(ctx)
+			wantID("Peek after a failed Seek", v, err, 2)
+
+			if err := i.Seek(ctx, 4); err != nil {
+				t.Errorf("want Seek(4) to succeed; got %v", err)
+			}
+			v, err = i.Next(ctx)
+			wantID("Next after Seek(4)", v, err, 5)
+			v, err = i.Prev(ctx)
+			wantID("Prev at the end of the list", v, err, 5)
+			v, err = i.Prev(ctx)
+			wantID("Prev back onto the second page", v, err, 4)
+			if err := i.Seek(ctx, 5); err != nil {
+				t.Errorf("want Seek(5) to succeed; got %v", err)
+			}
+
+			v, err = i.Next(ctx)
+			if !errors.Is(err, pokeapi.ErrListExhausted) || v != nil {
+				t.Errorf("want list to be exhausted; got (%v, %v)", v, err)
+			}
+
+			v, err = i.Prev(ctx)
+			if !errors.Is(err, pokeapi.ErrListExhausted) || v != nil {
+				t.Errorf("want Prev after exhaustion to return (nil, ErrListExhausted); got (%v, %v)", v, err)
+			}
+		},
+	)
 }
@@ -1,4 +1,4 @@
-//go:build goexperiment.rangefunc
+//go:build go1.23
 
 package iterator
 
@@ -10,22 +10,40 @@ import (
 	"github.com/nightmarlin/pokeapi"
 )
 
+// Seq returns an iter.Seq2 that yields every resource in the list at the
+// provided pokeapi.ResourceName. The Iterator backing the sequence is created
+// when iteration begins and stopped when it ends, so the returned sequence may
+// be ranged over more than once.
+//
+// If an error occurs (including the cancellation of ctx), it is yielded
+// alongside a nil resource and iteration ends.
 func Seq[R pokeapi.GettableAPIResource[T], T any](
 	ctx context.Context,
 	client *pokeapi.Client,
 	resource pokeapi.ResourceName[R, T],
 ) iter.Seq2[*T, error] {
-	i := New(client, resource)
-	defer i.Stop()
-
 	return func(yield func(*T, error) bool) {
-		v, err := i.Next(ctx)
-		if errors.Is(err, pokeapi.ErrListExhausted) {
-			return
-		}
+		i := New(client, resource)
+		defer i.Stop()
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			v, err := i.Next(ctx)
+			if errors.Is(err, pokeapi.ErrListExhausted) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
 
-		if !yield(v, err) {
-			return
+			if !yield(v, nil) {
+				return
+			}
 		}
 	}
 }
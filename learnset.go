@@ -0,0 +1,165 @@
+package pokeapi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// The names of the most common MoveLearnMethod s.
+const (
+	LearnMethodLevelUp = "level-up"
+	LearnMethodMachine = "machine"
+	LearnMethodEgg     = "egg"
+	LearnMethodTutor   = "tutor"
+)
+
+// A LearnedMove is a Move a Pokemon can learn, and how it learns it.
+type LearnedMove struct {
+	Move   NamedAPIResource[Move] `json:"move"`
+	Method string                 `json:"method"` // The name of the MoveLearnMethod.
+	Level  int                    `json:"level"`  // The level the Move is learnt at, or 0 if not learnt by levelling up.
+}
+
+// A Learnset holds the moves a Pokemon can learn in a single VersionGroup.
+type Learnset struct {
+	VersionGroup string `json:"version_group"`
+
+	// The moves that can be learnt, keyed by MoveLearnMethod name. Moves are
+	// ordered by level, then name. A move learnt at several levels appears once
+	// for each.
+	Moves map[string][]LearnedMove `json:"moves"`
+}
+
+// Learnset returns the moves the Pokemon can learn in the named VersionGroup,
+// grouped by MoveLearnMethod. If any methods are given, only moves learnt using
+// those methods are included.
+//
+//	ls := charmander.Learnset("scarlet-violet", pokeapi.LearnMethodLevelUp)
+//	for _, m := range ls.Moves[pokeapi.LearnMethodLevelUp] { ... }
+func (p Pokemon) Learnset(versionGroup string, methods ...string) Learnset {
+	ls := Learnset{VersionGroup: versionGroup, Moves: make(map[string][]LearnedMove)}
+
+	for _, pm := range p.Moves {
+		for _, d := range pm.VersionGroupDetails {
+			if d.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if len(methods) != 0 && !slices.Contains(methods, d.MoveLearnMethod.Name) {
+				continue
+			}
+
+			ls.Moves[d.MoveLearnMethod.Name] = append(
+				ls.Moves[d.MoveLearnMethod.Name],
+				LearnedMove{Move: pm.Move, Method: d.MoveLearnMethod.Name, Level: d.LevelLearnedAt},
+			)
+		}
+	}
+
+	for _, moves := range ls.Moves {
+		slices.SortStableFunc(moves, compareLearnedMoves)
+	}
+	return ls
+}
+
+func compareLearnedMoves(a, b LearnedMove) int {
+	return cmp.Or(
+		cmp.Compare(a.Method, b.Method),
+		cmp.Compare(a.Level, b.Level),
+		cmp.Compare(a.Move.Name, b.Move.Name),
+	)
+}
+
+// All returns every move in the Learnset, ordered by MoveLearnMethod name, then
+// level, then move name.
+func (ls Learnset) All() []LearnedMove {
+	var res []LearnedMove
+	for _, moves := range ls.Moves {
+		res = append(res, moves...)
+	}
+	slices.SortFunc(res, compareLearnedMoves)
+	return res
+}
+
+// Machines resolves the Machine that teaches each move in the Learnset learnt
+// using LearnMethodMachine, keyed by move name. The Machine.Item is the TM, TR
+// or HM itself - such as "tm35".
+//
+// Each Move, then each Machine, is retrieved using GetAll with the given
+// concurrency. Moves with no Machine in the Learnset's VersionGroup are
+// omitted.
+func (ls Learnset) Machines(ctx context.Context, c *Client, concurrency int) (map[string]*Machine, error) {
+	learnt := ls.Moves[LearnMethodMachine]
+	refs := make([]NamedAPIResource[Move], len(learnt))
+	for i, lm := range learnt {
+		refs[i] = lm.Move
+	}
+
+	moves, err := GetAll(ctx, c, refs, concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("getting moves: %w", err)
+	}
+
+	var machineRefs []APIResource[Machine]
+	for _, m := range moves {
+		for _, mvd := range m.Machines {
+			if mvd.VersionGroup.Name == ls.VersionGroup {
+				machineRefs = append(machineRefs, mvd.Machine)
+			}
+		}
+	}
+
+	machines, err := GetAll(ctx, c, machineRefs, concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("getting machines: %w", err)
+	}
+
+	res := make(map[string]*Machine, len(machines))
+	for _, m := range machines {
+		res[m.Move.Name] = m
+	}
+	return res, nil
+}
+
+// A LearnsetDiff holds the differences between two Learnset s.
+type LearnsetDiff struct {
+	Added   []LearnedMove // Moves only in the newer Learnset.
+	Removed []LearnedMove // Moves only in the older Learnset.
+}
+
+// Diff compares the Learnset to a newer one, such as the same Pokemon's
+// Learnset in a later VersionGroup. Moves are compared by name, method and
+// level - so a move learnt at a different level appears as both Removed and
+// Added.
+func (ls Learnset) Diff(newer Learnset) LearnsetDiff {
+	type key struct {
+		name, method string
+		level        int
+	}
+	keys := func(ls Learnset) map[key]bool {
+		res := make(map[key]bool)
+		for _, lm := range ls.All() {
+			res[key{lm.Move.Name, lm.Method, lm.Level}] = true
+		}
+		return res
+	}
+
+	var (
+		diff     LearnsetDiff
+		oldKeys  = keys(ls)
+		newKeys  = keys(newer)
+		onlyFrom = func(from Learnset, other map[key]bool) []LearnedMove {
+			var res []LearnedMove
+			for _, lm := range from.All() {
+				if !other[key{lm.Move.Name, lm.Method, lm.Level}] {
+					res = append(res, lm)
+				}
+			}
+			return res
+		}
+	)
+	diff.Added = onlyFrom(newer, oldKeys)
+	diff.Removed = onlyFrom(ls, newKeys)
+	return diff
+}
@@ -0,0 +1,163 @@
+package pokeapi_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+func learnedMove(move, method string, level int) pokeapi.LearnedMove {
+	return pokeapi.LearnedMove{
+		Move:   pokeapi.NamedAPIResource[pokeapi.Move]{Name: move},
+		Method: method,
+		Level:  level,
+	}
+}
+
+func pokemonMove(move pokeapi.NamedAPIResource[pokeapi.Move], details ...pokeapi.PokemonMoveVersion) pokeapi.PokemonMove {
+	return pokeapi.PokemonMove{Move: move, VersionGroupDetails: details}
+}
+
+func moveVersion(versionGroup, method string, level int) pokeapi.PokemonMoveVersion {
+	return pokeapi.PokemonMoveVersion{
+		MoveLearnMethod: pokeapi.NamedAPIResource[pokeapi.MoveLearnMethod]{Name: method},
+		VersionGroup:    pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: versionGroup},
+		LevelLearnedAt:  level,
+	}
+}
+
+func bareMoves(moves []pokeapi.LearnedMove) []pokeapi.LearnedMove {
+	res := make([]pokeapi.LearnedMove, len(moves))
+	for i, m := range moves {
+		res[i] = learnedMove(m.Move.Name, m.Method, m.Level)
+	}
+	return res
+}
+
+func TestPokemon_Learnset(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lm = pokeapi.LearnMethodLevelUp
+		mm = pokeapi.LearnMethodMachine
+		em = pokeapi.LearnMethodEgg
+
+		move = func(name string) pokeapi.NamedAPIResource[pokeapi.Move] {
+			return pokeapi.NamedAPIResource[pokeapi.Move]{Name: name}
+		}
+		charmander = pokeapi.Pokemon{
+			Moves: []pokeapi.PokemonMove{
+				pokemonMove(move("ember"), moveVersion("red-blue", lm, 9), moveVersion("scarlet-violet", lm, 4)),
+				pokemonMove(move("scratch"), moveVersion("red-blue", lm, 1), moveVersion("scarlet-violet", lm, 1)),
+				pokemonMove(move("growl"), moveVersion("red-blue", lm, 1), moveVersion("scarlet-violet", lm, 1)),
+				pokemonMove(move("flamethrower"), moveVersion("red-blue", lm, 38), moveVersion("scarlet-violet", mm, 0)),
+				pokemonMove(move("dragon-dance"), moveVersion("scarlet-violet", em, 0)),
+				pokemonMove(move("rage"), moveVersion("red-blue", lm, 15)),
+			},
+		}
+	)
+
+	t.Run(
+		"groups moves by method and sorts them by level",
+		func(t *testing.T) {
+			t.Parallel()
+
+			ls := charmander.Learnset("scarlet-violet")
+			want := map[string][]pokeapi.LearnedMove{
+				lm: {learnedMove("growl", lm, 1), learnedMove("scratch", lm, 1), learnedMove("ember", lm, 4)},
+				mm: {learnedMove("flamethrower", mm, 0)},
+				em: {learnedMove("dragon-dance", em, 0)},
+			}
+			if !reflect.DeepEqual(ls.Moves, want) {
+				t.Errorf("want moves %v; got %v", want, ls.Moves)
+			}
+
+			ls = charmander.Learnset("scarlet-violet", lm)
+			if len(ls.Moves) != 1 || len(ls.Moves[lm]) != 3 {
+				t.Errorf("want only 3 level-up moves; got %v", ls.Moves)
+			}
+		},
+	)
+
+	t.Run(
+		"diffs learnsets between version groups",
+		func(t *testing.T) {
+			t.Parallel()
+
+			diff := charmander.Learnset("red-blue").Diff(charmander.Learnset("scarlet-violet"))
+			wantAdded := []pokeapi.LearnedMove{
+				learnedMove("dragon-dance", em, 0),
+				learnedMove("ember", lm, 4),
+				learnedMove("flamethrower", mm, 0),
+			}
+			wantRemoved := []pokeapi.LearnedMove{
+				learnedMove("ember", lm, 9),
+				learnedMove("rage", lm, 15),
+				learnedMove("flamethrower", lm, 38),
+			}
+			if got := bareMoves(diff.Added); !reflect.DeepEqual(got, wantAdded) {
+				t.Errorf("want added %v; got %v", wantAdded, got)
+			}
+			if got := bareMoves(diff.Removed); !reflect.DeepEqual(got, wantRemoved) {
+				t.Errorf("want removed %v; got %v", wantRemoved, got)
+			}
+		},
+	)
+
+	t.Run(
+		"resolves machines for the version group",
+		func(t *testing.T) {
+			t.Parallel()
+
+			rs, c := pokeapitest.NewServer(t)
+
+			rs.Add(
+				"/machine/1/",
+				pokeapi.Machine{
+					Item:         pokeapi.NamedAPIResource[pokeapi.Item]{Name: "tm125"},
+					Move:         move("flamethrower"),
+					VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: "scarlet-violet"},
+				},
+			)
+			rs.Add(
+				"/move/53/",
+				pokeapi.Move{
+					NamedIdentifier: pokeapi.NamedIdentifier{Name: "flamethrower"},
+					Machines: []pokeapi.MachineVersionDetail{
+						{
+							Machine:      pokeapi.APIResource[pokeapi.Machine]{URL: rs.URL + "/machine/2/"},
+							VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: "sword-shield"},
+						},
+						{
+							Machine:      pokeapi.APIResource[pokeapi.Machine]{URL: rs.URL + "/machine/1/"},
+							VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: "scarlet-violet"},
+						},
+					},
+				},
+			)
+
+			p := pokeapi.Pokemon{
+				Moves: []pokeapi.PokemonMove{
+					pokemonMove(
+						namedRef[pokeapi.Move](rs, "/move/53/", "flamethrower"),
+						moveVersion("scarlet-violet", mm, 0),
+					),
+				},
+			}
+
+			machines, err := p.Learnset("scarlet-violet").Machines(context.Background(), c, 2)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if m, ok := machines["flamethrower"]; !ok || m.Item.Name != "tm125" {
+				t.Errorf("want flamethrower to be taught by tm125; got %v", machines)
+			}
+			if n := rs.Requests("/machine/2/"); n != 0 {
+				t.Errorf("want machines from other version groups not to be requested; got %d requests", n)
+			}
+		},
+	)
+}
@@ -0,0 +1,160 @@
+package pokeapi
+
+import (
+	"strings"
+)
+
+// DefaultLanguage is the Language name that Localize falls back to when none of
+// the preferred languages are available.
+const DefaultLanguage = "en"
+
+// Localizable is implemented by every localized entry - such as Name,
+// FlavorText and Genus - so that it may be selected by Localize.
+type Localizable interface {
+	LanguageName() string // The name of the Language the entry is written in.
+}
+
+func (n Name) LanguageName() string                    { return n.Language.Name }
+func (d Description) LanguageName() string             { return d.Language.Name }
+func (e Effect) LanguageName() string                  { return e.Language.Name }
+func (e VerboseEffect) LanguageName() string           { return e.Language.Name }
+func (ft FlavorText) LanguageName() string             { return ft.Language.Name }
+func (ft VersionGroupFlavorText) LanguageName() string { return ft.Language.Name }
+func (ft MoveFlavorText) LanguageName() string         { return ft.Language.Name }
+func (ft AbilityFlavorText) LanguageName() string      { return ft.Language.Name }
+func (g Genus) LanguageName() string                   { return g.Language.Name }
+func (an AwesomeName) LanguageName() string            { return an.Language.Name }
+func (cn ContestName) LanguageName() string            { return cn.Language.Name }
+
+// VersionGroupLocalizable is implemented by localized entries that are specific
+// to a VersionGroup, so that they may be selected by
+// LocalizeVersionGroupFlavorText.
+type VersionGroupLocalizable interface {
+	Localizable
+	VersionGroupName() string // The name of the VersionGroup the entry is used in.
+}
+
+func (ft VersionGroupFlavorText) VersionGroupName() string { return ft.VersionGroup.Name }
+func (ft MoveFlavorText) VersionGroupName() string         { return ft.VersionGroup.Name }
+func (ft AbilityFlavorText) VersionGroupName() string      { return ft.VersionGroup.Name }
+
+// parentTag removes the last subtag from a BCP-47 style language tag, returning
+// "" once there are none left - so "zh-Hant-HK" becomes "zh-Hant" then "zh".
+func parentTag(tag string) string {
+	i := strings.LastIndex(tag, "-")
+	if i < 0 {
+		return ""
+	}
+	return tag[:i]
+}
+
+func primaryTag(tag string) string {
+	if i := strings.Index(tag, "-"); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+// localize selects the entry in the most preferred language available.
+//
+// Each preference is tried exactly, then with its subtags removed one by one,
+// then against any language sharing its primary subtag - so "zh-Hant" may match
+// "zh-Hant", "zh" and then "zh-Hans". DefaultLanguage is tried last.
+//
+// Within a language, entries satisfying prefer (if not nil) are chosen over
+// those that don't. If latest is set, later entries are chosen over earlier
+// ones.
+func localize[E Localizable](entries []E, langPrefs []string, prefer func(E) bool, latest bool) (E, bool) {
+	find := func(match func(lang string) bool) (E, bool) {
+		var (
+			fallback E
+			found    bool
+		)
+		for i := range entries {
+			e := entries[i]
+			if latest {
+				e = entries[len(entries)-1-i]
+			}
+			if !match(e.LanguageName()) {
+				continue
+			}
+			if prefer == nil || prefer(e) {
+				return e, true
+			}
+			if !found {
+				fallback, found = e, true
+			}
+		}
+		return fallback, found
+	}
+
+	for _, pref := range append(langPrefs[:len(langPrefs):len(langPrefs)], DefaultLanguage) {
+		for tag := pref; tag != ""; tag = parentTag(tag) {
+			if e, ok := find(func(lang string) bool { return strings.EqualFold(lang, tag) }); ok {
+				return e, true
+			}
+		}
+
+		primary := primaryTag(pref)
+		if e, ok := find(func(lang string) bool { return strings.EqualFold(primaryTag(lang), primary) }); ok {
+			return e, true
+		}
+	}
+
+	var zero E
+	return zero, false
+}
+
+// Localize selects the entry written in the most preferred language available,
+// falling back to DefaultLanguage. Language preferences are the names of
+// Language s, which are BCP-47 style tags such as "fr", "ja-Hrkt" or "zh-Hant".
+//
+// A preference matches its own language first, then its parent languages (such
+// as "zh" for "zh-Hant"), then its sibling languages (such as "zh-Hans" for
+// "zh-Hant"). If there is no entry in any of those languages, nor in
+// DefaultLanguage, false is returned.
+//
+//	name, ok := pokeapi.Localize(species.Names, "zh-Hant")
+//	genus, ok := pokeapi.Localize(species.Genera, "ja")
+func Localize[E Localizable](entries []E, langPrefs ...string) (E, bool) {
+	return localize(entries, langPrefs, nil, false)
+}
+
+// LocalizeFlavorText selects the FlavorText written in the most preferred
+// language available, as Localize does, and returns its
+// FlavorText.NormalizedFlavorText.
+//
+// Within a language, the FlavorText from the named Version is preferred. If
+// version is "" or there is no FlavorText from that Version, the latest one is
+// used instead.
+func LocalizeFlavorText(entries []FlavorText, version string, langPrefs ...string) (string, bool) {
+	ft, ok := localize(
+		entries,
+		langPrefs,
+		func(ft FlavorText) bool { return ft.Version.Name == version },
+		true,
+	)
+	if !ok {
+		return "", false
+	}
+	return ft.NormalizedFlavorText(), true
+}
+
+// LocalizeVersionGroupFlavorText selects the entry written in the most
+// preferred language available, as Localize does.
+//
+// Within a language, the entry from the named VersionGroup is preferred. If
+// versionGroup is "" or there is no entry from that VersionGroup, the latest one
+// is used instead.
+func LocalizeVersionGroupFlavorText[E VersionGroupLocalizable](
+	entries []E,
+	versionGroup string,
+	langPrefs ...string,
+) (E, bool) {
+	return localize(
+		entries,
+		langPrefs,
+		func(e E) bool { return e.VersionGroupName() == versionGroup },
+		true,
+	)
+}
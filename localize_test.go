@@ -0,0 +1,89 @@
+package pokeapi_test
+
+import (
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+func lang(name string) pokeapi.NamedAPIResource[pokeapi.Language] {
+	return pokeapi.NamedAPIResource[pokeapi.Language]{Name: name}
+}
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	names := []pokeapi.Name{
+		{Name: "Pikachu", Language: lang("en")},
+		{Name: "ピカチュウ", Language: lang("ja-Hrkt")},
+		{Name: "皮卡丘", Language: lang("zh-Hans")},
+		{Name: "Pikachu (fr)", Language: lang("fr")},
+	}
+
+	for _, tc := range []struct {
+		prefs []string
+		want  string
+	}{
+		{prefs: nil, want: "Pikachu"},
+		{prefs: []string{"fr"}, want: "Pikachu (fr)"},
+		{prefs: []string{"FR"}, want: "Pikachu (fr)"},
+		{prefs: []string{"de", "fr"}, want: "Pikachu (fr)"},
+		{prefs: []string{"zh-Hant"}, want: "皮卡丘"},
+		{prefs: []string{"ja"}, want: "ピカチュウ"},
+		{prefs: []string{"ko"}, want: "Pikachu"},
+	} {
+		got, ok := pokeapi.Localize(names, tc.prefs...)
+		if !ok || got.Name != tc.want {
+			t.Errorf("%v: want (%q, true); got (%q, %t)", tc.prefs, tc.want, got.Name, ok)
+		}
+	}
+
+	if got, ok := pokeapi.Localize([]pokeapi.Genus{{Genus: "Maus", Language: lang("de")}}, "fr"); ok {
+		t.Errorf("want no genus without a preferred or default language; got %+v", got)
+	}
+
+	t.Run(
+		"prefers flavor text from the version, then the latest",
+		func(t *testing.T) {
+			t.Parallel()
+
+			version := func(name string) pokeapi.NamedAPIResource[pokeapi.Version] {
+				return pokeapi.NamedAPIResource[pokeapi.Version]{Name: name}
+			}
+			entries := []pokeapi.FlavorText{
+				{FlavorText: "Red\nentry", Language: lang("en"), Version: version("red")},
+				{FlavorText: "Sword entry", Language: lang("en"), Version: version("sword")},
+				{FlavorText: "Entrée rouge", Language: lang("fr"), Version: version("red")},
+				{FlavorText: "Entrée épée", Language: lang("fr"), Version: version("sword")},
+			}
+
+			for _, tc := range []struct {
+				version string
+				prefs   []string
+				want    string
+			}{
+				{version: "red", want: "Red entry"},
+				{version: "", want: "Sword entry"},
+				{version: "scarlet", want: "Sword entry"},
+				{version: "red", prefs: []string{"fr"}, want: "Entrée rouge"},
+				{version: "scarlet", prefs: []string{"fr"}, want: "Entrée épée"},
+			} {
+				got, ok := pokeapi.LocalizeFlavorText(entries, tc.version, tc.prefs...)
+				if !ok || got != tc.want {
+					t.Errorf("%q %v: want (%q, true); got (%q, %t)", tc.version, tc.prefs, tc.want, got, ok)
+				}
+			}
+
+			vgEntries := []pokeapi.MoveFlavorText{
+				{FlavorText: "Old", Language: lang("en"), VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: "red-blue"}},
+				{FlavorText: "New", Language: lang("en"), VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: "sword-shield"}},
+			}
+			if got, ok := pokeapi.LocalizeVersionGroupFlavorText(vgEntries, "red-blue"); !ok || got.FlavorText != "Old" {
+				t.Errorf("want (Old, true); got (%q, %t)", got.FlavorText, ok)
+			}
+			if got, ok := pokeapi.LocalizeVersionGroupFlavorText(vgEntries, ""); !ok || got.FlavorText != "New" {
+				t.Errorf("want (New, true); got (%q, %t)", got.FlavorText, ok)
+			}
+		},
+	)
+}
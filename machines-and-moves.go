@@ -80,6 +80,7 @@ type Move struct {
 	EffectEntries     []VerboseEffect       `json:"effect_entries"`
 	EffectChanges     []AbilityEffectChange `json:"effect_changes"`
 	FlavorTextEntries []MoveFlavorText      `json:"flavor_text_entries"`
+	Names             []Name                `json:"names"`
 }
 
 type MoveAilment struct {
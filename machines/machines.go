@@ -0,0 +1,180 @@
+// Package machines indexes every pokeapi.Machine - the TMs, HMs and TRs that
+// teach moves - by pokeapi.VersionGroup, so that questions like "what is TM24
+// in sword-shield?" or "which machine teaches thunderbolt in red-blue?" can be
+// answered without following pokeapi.MachineVersionDetail references by hand.
+//
+// Machines are retrieved using the pokeapi.Client, so they are cached by it as
+// any other resource would be.
+package machines
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// Opts configure Build and Index.Resolve.
+type Opts struct {
+	// The number of machines to retrieve at once. Default
+	// pokeapi.DefaultConcurrency.
+	Concurrency int
+}
+
+func (o *Opts) concurrency() int {
+	if o == nil || o.Concurrency < 1 {
+		return pokeapi.DefaultConcurrency
+	}
+	return o.Concurrency
+}
+
+// key identifies a machine, or the machines teaching a move, in a version
+// group.
+type key struct {
+	versionGroup, name string
+}
+
+// An Index holds pokeapi.Machine s by the pokeapi.VersionGroup they belong to,
+// and both the pokeapi.Item they are and the pokeapi.Move they teach. The zero
+// value is an empty Index ready for use. It is safe for concurrent use.
+type Index struct {
+	mux    sync.RWMutex
+	byID   map[int]*pokeapi.Machine
+	byItem map[key]*pokeapi.Machine
+	byMove map[key][]*pokeapi.Machine
+}
+
+// New returns an Index of the machines.
+func New(ms ...*pokeapi.Machine) *Index {
+	var ix Index
+	ix.Add(ms...)
+	return &ix
+}
+
+// Build lists and retrieves every pokeapi.Machine using the pokeapi.Client, and
+// returns an Index of them. A nil Opts uses the defaults.
+//
+// If some machines could not be retrieved, the Index of those that could be is
+// returned alongside the *pokeapi.GetAllError.
+func Build(ctx context.Context, c *pokeapi.Client, opts *Opts) (*Index, error) {
+	refs, err := pokeapi.ListAll(ctx, c, pokeapi.MachineResource, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ms, err := pokeapi.GetAll(ctx, c, refs, opts.concurrency())
+	if err != nil {
+		return New(ms...), fmt.Errorf("getting machines: %w", err)
+	}
+	return New(ms...), nil
+}
+
+// Add adds the machines to the Index. Adding a machine again has no effect.
+func (ix *Index) Add(ms ...*pokeapi.Machine) {
+	ix.mux.Lock()
+	defer ix.mux.Unlock()
+
+	if ix.byID == nil {
+		ix.byID = make(map[int]*pokeapi.Machine, len(ms))
+		ix.byItem = make(map[key]*pokeapi.Machine, len(ms))
+		ix.byMove = make(map[key][]*pokeapi.Machine, len(ms))
+	}
+
+	for _, m := range ms {
+		if m == nil {
+			continue
+		}
+		if _, ok := ix.byID[m.ID]; ok {
+			continue
+		}
+
+		ix.byID[m.ID] = m
+		ix.byItem[key{versionGroup: m.VersionGroup.Name, name: m.Item.Name}] = m
+
+		k := key{versionGroup: m.VersionGroup.Name, name: m.Move.Name}
+		ix.byMove[k] = append(ix.byMove[k], m)
+	}
+}
+
+// Resolve retrieves the pokeapi.Machine referenced by each
+// pokeapi.MachineVersionDetail using the pokeapi.Client, and adds them to the
+// Index. Machines already in the Index are not retrieved again. A nil Opts uses
+// the defaults.
+//
+// This allows the Index to be filled lazily, rather than by Build:
+//
+//	err := ix.Resolve(ctx, c, move.Machines, nil)
+func (ix *Index) Resolve(
+	ctx context.Context,
+	c *pokeapi.Client,
+	details []pokeapi.MachineVersionDetail,
+	opts *Opts,
+) error {
+	ix.mux.RLock()
+	var refs []pokeapi.APIResource[pokeapi.Machine]
+	for _, d := range details {
+		if _, ok := ix.byID[d.Machine.ID()]; !ok {
+			refs = append(refs, d.Machine)
+		}
+	}
+	ix.mux.RUnlock()
+
+	ms, err := pokeapi.GetAll(ctx, c, refs, opts.concurrency())
+	ix.Add(ms...)
+	if err != nil {
+		return fmt.Errorf("getting machines: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of machines in the Index.
+func (ix *Index) Len() int {
+	ix.mux.RLock()
+	defer ix.mux.RUnlock()
+	return len(ix.byID)
+}
+
+// Item returns the machine that is the named pokeapi.Item - such as "tm24" -
+// in the named pokeapi.VersionGroup. Item names are matched ignoring case.
+//
+//	m, ok := ix.Item("sword-shield", "TM24")
+func (ix *Index) Item(versionGroup, item string) (*pokeapi.Machine, bool) {
+	ix.mux.RLock()
+	defer ix.mux.RUnlock()
+
+	m, ok := ix.byItem[key{versionGroup: versionGroup, name: strings.ToLower(item)}]
+	return m, ok
+}
+
+// Move returns the machines that teach the named pokeapi.Move in the named
+// pokeapi.VersionGroup, ordered by ID. It is usually one machine, or none.
+func (ix *Index) Move(versionGroup, move string) []*pokeapi.Machine {
+	ix.mux.RLock()
+	defer ix.mux.RUnlock()
+
+	res := slices.Clone(ix.byMove[key{versionGroup: versionGroup, name: move}])
+	slices.SortFunc(res, compareMachines)
+	return res
+}
+
+// VersionGroup returns every machine in the named pokeapi.VersionGroup, ordered
+// by ID.
+func (ix *Index) VersionGroup(versionGroup string) []*pokeapi.Machine {
+	ix.mux.RLock()
+	defer ix.mux.RUnlock()
+
+	var res []*pokeapi.Machine
+	for _, m := range ix.byID {
+		if m.VersionGroup.Name == versionGroup {
+			res = append(res, m)
+		}
+	}
+	slices.SortFunc(res, compareMachines)
+	return res
+}
+
+func compareMachines(a, b *pokeapi.Machine) int { return cmp.Compare(a.ID, b.ID) }
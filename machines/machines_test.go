@@ -0,0 +1,140 @@
+package machines_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/machines"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+)
+
+// server serves three machines, and counts the requests made for each path.
+func server(t *testing.T) (c *pokeapi.Client, url func(id int) string, requests func(path string) int) {
+	t.Helper()
+
+	ts, c := pokeapitest.NewServer(t)
+	url = func(id int) string { return ts.URL + "/machine/" + strconv.Itoa(id) + "/" }
+
+	p := pokeapi.Page[pokeapi.APIResource[pokeapi.Machine], pokeapi.Machine]{}
+	for id, m := range []pokeapi.Machine{
+		machine(1, "tm24", "thunderbolt", "red-blue"),
+		machine(2, "tm24", "dragon-breath", "gold-silver"),
+		machine(3, "tm24", "thunderbolt", "ruby-sapphire"),
+	} {
+		p.Count++
+		p.Results = append(p.Results, pokeapi.APIResource[pokeapi.Machine]{URL: ts.Add("/machine/"+strconv.Itoa(id+1)+"/", m)})
+	}
+	ts.Add("/machine/", p)
+
+	return c, url, ts.Requests
+}
+
+func machine(id int, item, move, versionGroup string) pokeapi.Machine {
+	return pokeapi.Machine{
+		Identifier:   pokeapi.Identifier{ID: id},
+		Item:         pokeapi.NamedAPIResource[pokeapi.Item]{Name: item},
+		Move:         pokeapi.NamedAPIResource[pokeapi.Move]{Name: move},
+		VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: versionGroup},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	c, _, _ := server(t)
+	ix, err := machines.Build(context.Background(), c, nil)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	if ix.Len() != 3 {
+		t.Errorf("want 3 machines; got %d", ix.Len())
+	}
+
+	if m, ok := ix.Item("gold-silver", "TM24"); !ok || m.Move.Name != "dragon-breath" {
+		t.Errorf("want (dragon-breath, true); got (%+v, %t)", m, ok)
+	}
+	if m, ok := ix.Item("sword-shield", "tm24"); ok {
+		t.Errorf("want (nil, false); got (%+v, %t)", m, ok)
+	}
+
+	if ms := ix.Move("ruby-sapphire", "thunderbolt"); len(ms) != 1 || ms[0].ID != 3 {
+		t.Errorf("want [machine 3]; got %+v", ms)
+	}
+	if ms := ix.Move("gold-silver", "thunderbolt"); len(ms) != 0 {
+		t.Errorf("want no machines; got %+v", ms)
+	}
+
+	if ms := ix.VersionGroup("red-blue"); len(ms) != 1 || ms[0].Item.Name != "tm24" {
+		t.Errorf("want [tm24]; got %+v", ms)
+	}
+}
+
+func TestBuild_partial(t *testing.T) {
+	t.Parallel()
+
+	ts, c := pokeapitest.NewServer(t)
+	ts.Add(
+		"/machine/",
+		pokeapi.Page[pokeapi.APIResource[pokeapi.Machine], pokeapi.Machine]{
+			Count: 2,
+			Results: []pokeapi.APIResource[pokeapi.Machine]{
+				{URL: ts.Add("/machine/1/", machine(1, "tm24", "thunderbolt", "red-blue"))},
+				{URL: ts.URL + "/machine/2/"},
+			},
+		},
+	)
+
+	ix, err := machines.Build(context.Background(), c, nil)
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		t.Errorf("want ErrNotFound for the missing machine; got %v", err)
+	}
+	if ix == nil || ix.Len() != 1 {
+		t.Fatalf("want an index of the 1 machine retrieved; got %+v", ix)
+	}
+	if m, ok := ix.Item("red-blue", "tm24"); !ok || m.Move.Name != "thunderbolt" {
+		t.Errorf("want (thunderbolt, true); got (%+v, %t)", m, ok)
+	}
+}
+
+func TestIndex_Resolve(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx                = context.Background()
+		c, url, requests   = server(t)
+		ix                 machines.Index
+		thunderboltDetails = []pokeapi.MachineVersionDetail{
+			{
+				Machine:      pokeapi.APIResource[pokeapi.Machine]{URL: url(1)},
+				VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: "red-blue"},
+			},
+			{
+				Machine:      pokeapi.APIResource[pokeapi.Machine]{URL: url(3)},
+				VersionGroup: pokeapi.NamedAPIResource[pokeapi.VersionGroup]{Name: "ruby-sapphire"},
+			},
+		}
+	)
+
+	for range 2 {
+		if err := ix.Resolve(ctx, c, thunderboltDetails, nil); err != nil {
+			t.Fatalf("want no error; got %v", err)
+		}
+	}
+
+	if ix.Len() != 2 {
+		t.Errorf("want 2 machines; got %d", ix.Len())
+	}
+	if m, ok := ix.Item("red-blue", "tm24"); !ok || m.Move.Name != "thunderbolt" {
+		t.Errorf("want (thunderbolt, true); got (%+v, %t)", m, ok)
+	}
+	if n := requests("/machine/1/"); n != 1 {
+		t.Errorf("want machine 1 to be requested once; got %d requests", n)
+	}
+	if n := requests("/machine/2/"); n != 0 {
+		t.Errorf("want machine 2 not to be requested; got %d requests", n)
+	}
+}
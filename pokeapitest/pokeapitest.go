@@ -0,0 +1,77 @@
+// Package pokeapitest provides a fake PokéAPI for testing code that retrieves
+// resources using a [pokeapi.Client], without making requests to PokéAPI.
+//
+// As it imports package testing, pokeapitest should not be used in normal
+// application code.
+package pokeapitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// A Server serves the JSON encoding of the value added at each path, and
+// responds 404 Not Found to any other request. It records how many times each
+// path is requested. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mux       sync.Mutex
+	resources map[string]any
+	requests  map[string]int
+}
+
+// NewServer starts a Server, which is closed when the test completes, and
+// returns it along with a pokeapi.Client that uses it as its PokéAPI root.
+func NewServer(t testing.TB) (*Server, *pokeapi.Client) {
+	t.Helper()
+
+	s := &Server{resources: make(map[string]any), requests: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+
+	return s, pokeapi.NewClient(
+		&pokeapi.ClientOpts{HTTPClient: s.Client(), PokeAPIRoot: s.URL},
+	)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mux.Lock()
+	s.requests[r.URL.Path]++
+	v, ok := s.resources[r.URL.RequestURI()]
+	if !ok {
+		v, ok = s.resources[r.URL.Path]
+	}
+	s.mux.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Add serves v at the path, and returns the URL it is served at. A path with a
+// query, such as "/pokemon/?offset=20", is only served to requests with exactly
+// that query; a path without one is served whatever the query is.
+func (s *Server) Add(path string, v any) string {
+	defer s.mux.Unlock()
+	s.mux.Lock()
+
+	s.resources[path] = v
+	return s.URL + path
+}
+
+// Requests returns the number of requests made for the path, ignoring any
+// query.
+func (s *Server) Requests(path string) int {
+	defer s.mux.Unlock()
+	s.mux.Lock()
+
+	return s.requests[path]
+}
@@ -211,6 +211,22 @@ func (p Pokemon) HeightMillimeters() int { return p.Height * 100 }
 // WeightGrams converts Pokemon.Weight (in hectograms) to the more common measurement grams.
 func (p Pokemon) WeightGrams() int { return p.Weight * 100 }
 
+// AssetURLs returns the URL of every sprite and cry of the Pokemon, so that they
+// can be downloaded by sprites.Store.Prefetch.
+func (p Pokemon) AssetURLs() []string {
+	var res []string
+	for _, u := range p.Sprites.URLs() {
+		res = append(res, string(u))
+	}
+	if p.Cries.Latest != "" {
+		res = append(res, p.Cries.Latest)
+	}
+	if p.Cries.Legacy != nil && *p.Cries.Legacy != "" {
+		res = append(res, *p.Cries.Legacy)
+	}
+	return res
+}
+
 type PokemonLocationArea struct {
 	LocationArea   NamedAPIResource[LocationArea] `json:"location_area"`
 	VersionDetails []VersionEncounterDetail       `json:"version_details"`
@@ -263,6 +279,16 @@ type PokemonForm struct {
 	FormNames    []Name                         `json:"form_names"`    // The form specific full name of this Pokémon form, or empty if the form does not have a specific name.
 }
 
+// AssetURLs returns the URL of every sprite of the PokemonForm, so that they can
+// be downloaded by sprites.Store.Prefetch.
+func (pf PokemonForm) AssetURLs() []string {
+	var res []string
+	for _, u := range pf.Sprites.URLs() {
+		res = append(res, string(u))
+	}
+	return res
+}
+
 type PokemonHabitat struct {
 	NamedIdentifier
 
@@ -289,8 +315,8 @@ type Genus struct {
 }
 
 type PokemonSpeciesDexEntry struct {
-	EntryNumber int                         `json:"entry_number"`
-	Pokedex     []NamedAPIResource[Pokedex] `json:"pokedex"`
+	EntryNumber int                       `json:"entry_number"`
+	Pokedex     NamedAPIResource[Pokedex] `json:"pokedex"`
 }
 
 type PalParkEncounterArea struct {
@@ -0,0 +1,20 @@
+// Package query finds every resource matching a set of Predicate s, such as
+// "all fire-type Pokémon with a base speed of at least 100":
+//
+//	q := query.New(
+//		pokeapi.PokemonResource,
+//		query.PokemonHasType("fire"),
+//		query.PokemonBaseStat(stats.Speed, query.Ge, 100),
+//	)
+//	for p, err := range q.Run(ctx, c) { ... }
+//
+// To gather every result at once, use iterator.Collect:
+//
+//	ps, err := iterator.Collect(q.Run(ctx, c))
+//
+// Where a Predicate can be answered by a narrower list of resources - such as
+// pokeapi.Type.Pokemon for PokemonHasType - only the resources in that list are
+// retrieved, rather than every resource at the pokeapi.ResourceName.
+//
+// As results are streamed using iter.Seq2, this package requires go1.23.
+package query
@@ -0,0 +1,282 @@
+//go:build go1.23
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/stats"
+)
+
+// An Op compares a field of a resource against a value.
+type Op int
+
+// The comparisons an Op can make.
+const (
+	Eq Op = iota + 1 // Equal to.
+	Ne               // Not equal to.
+	Lt               // Less than.
+	Le               // Less than or equal to.
+	Gt               // Greater than.
+	Ge               // Greater than or equal to.
+)
+
+func (o Op) compare(a, b int) bool {
+	switch o {
+	case Eq:
+		return a == b
+	case Ne:
+		return a != b
+	case Lt:
+		return a < b
+	case Le:
+		return a <= b
+	case Gt:
+		return a > b
+	case Ge:
+		return a >= b
+	default:
+		return false
+	}
+}
+
+// sourceOf returns a Source listing the references get extracts from the named
+// resource, retrieved using the pokeapi.ResourceName.
+func sourceOf[R, T any](
+	rn pokeapi.ResourceName[pokeapi.NamedAPIResource[R], R],
+	name string,
+	get func(*R) []pokeapi.NamedAPIResource[T],
+) Source[T] {
+	return func(ctx context.Context, c *pokeapi.Client) ([]pokeapi.NamedAPIResource[T], error) {
+		r, err := rn.Get(ctx, c, name)
+		if err != nil {
+			return nil, fmt.Errorf("getting %s %q: %w", rn, name, err)
+		}
+		return get(r), nil
+	}
+}
+
+// PokemonHasType matches pokeapi.Pokemon with the named pokeapi.Type. Its Source
+// is pokeapi.Type.Pokemon.
+func PokemonHasType(name string) Predicate[pokeapi.Pokemon] {
+	return Predicate[pokeapi.Pokemon]{
+		Match: func(_ context.Context, _ *pokeapi.Client, p *pokeapi.Pokemon) (bool, error) {
+			return slices.ContainsFunc(p.Types, func(pt pokeapi.PokemonType) bool { return pt.Type.Name == name }), nil
+		},
+		Source: sourceOf(
+			pokeapi.TypeResource, name,
+			func(t *pokeapi.Type) []pokeapi.NamedAPIResource[pokeapi.Pokemon] {
+				res := make([]pokeapi.NamedAPIResource[pokeapi.Pokemon], len(t.Pokemon))
+				for i, tp := range t.Pokemon {
+					res[i] = tp.Pokemon
+				}
+				return res
+			},
+		),
+	}
+}
+
+// PokemonHasAbility matches pokeapi.Pokemon that can have the named
+// pokeapi.Ability. Its Source is pokeapi.Ability.Pokemon.
+func PokemonHasAbility(name string) Predicate[pokeapi.Pokemon] {
+	return Predicate[pokeapi.Pokemon]{
+		Match: func(_ context.Context, _ *pokeapi.Client, p *pokeapi.Pokemon) (bool, error) {
+			return slices.ContainsFunc(p.Abilities, func(pa pokeapi.PokemonAbility) bool { return pa.Ability.Name == name }), nil
+		},
+		Source: sourceOf(
+			pokeapi.AbilityResource, name,
+			func(a *pokeapi.Ability) []pokeapi.NamedAPIResource[pokeapi.Pokemon] {
+				res := make([]pokeapi.NamedAPIResource[pokeapi.Pokemon], len(a.Pokemon))
+				for i, ap := range a.Pokemon {
+					res[i] = ap.Pokemon
+				}
+				return res
+			},
+		),
+	}
+}
+
+// PokemonLearns matches pokeapi.Pokemon that can learn the named pokeapi.Move,
+// in any pokeapi.VersionGroup. Its Source is pokeapi.Move.LearnedByPokemon.
+func PokemonLearns(move string) Predicate[pokeapi.Pokemon] {
+	return Predicate[pokeapi.Pokemon]{
+		Match: func(_ context.Context, _ *pokeapi.Client, p *pokeapi.Pokemon) (bool, error) {
+			return slices.ContainsFunc(p.Moves, func(pm pokeapi.PokemonMove) bool { return pm.Move.Name == move }), nil
+		},
+		Source: sourceOf(
+			pokeapi.MoveResource, move,
+			func(m *pokeapi.Move) []pokeapi.NamedAPIResource[pokeapi.Pokemon] { return m.LearnedByPokemon },
+		),
+	}
+}
+
+// PokemonBaseStat matches pokeapi.Pokemon whose base value of the named stat -
+// one of stats.Names - compares to value using the Op.
+func PokemonBaseStat(stat string, op Op, value int) Predicate[pokeapi.Pokemon] {
+	return Func(
+		func(p *pokeapi.Pokemon) bool {
+			v, ok := stats.Base(p).Get(stat)
+			return ok && op.compare(v, value)
+		},
+	)
+}
+
+// PokemonBaseStatTotal matches pokeapi.Pokemon whose base stat total compares
+// to value using the Op.
+func PokemonBaseStatTotal(op Op, value int) Predicate[pokeapi.Pokemon] {
+	return Func(func(p *pokeapi.Pokemon) bool { return op.compare(stats.Base(p).Total(), value) })
+}
+
+// PokemonSpecies matches pokeapi.Pokemon whose pokeapi.PokemonSpecies matches
+// the Predicate. Each species is retrieved using the pokeapi.Client. If the
+// Predicate has a Source, the Source of the returned Predicate lists the
+// pokeapi.PokemonSpeciesVariety s of each species it lists.
+//
+//	query.PokemonSpecies(query.SpeciesInGeneration("generation-iv"))
+func PokemonSpecies(pred Predicate[pokeapi.PokemonSpecies]) Predicate[pokeapi.Pokemon] {
+	res := Predicate[pokeapi.Pokemon]{
+		Match: func(ctx context.Context, c *pokeapi.Client, p *pokeapi.Pokemon) (bool, error) {
+			s, err := p.Species.Get(ctx, c)
+			if err != nil {
+				return false, fmt.Errorf("getting species of %q: %w", p.Name, err)
+			}
+			return pred.match(ctx, c, s)
+		},
+	}
+	if pred.Source != nil {
+		res.Source = varieties(pred.Source)
+	}
+	return res
+}
+
+// varieties returns a Source listing the pokeapi.Pokemon of every variety of the
+// species the Source lists.
+func varieties(source Source[pokeapi.PokemonSpecies]) Source[pokeapi.Pokemon] {
+	return func(ctx context.Context, c *pokeapi.Client) ([]pokeapi.NamedAPIResource[pokeapi.Pokemon], error) {
+		refs, err := source(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		species, err := pokeapi.GetAll(ctx, c, refs, pokeapi.DefaultConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("getting species: %w", err)
+		}
+
+		var res []pokeapi.NamedAPIResource[pokeapi.Pokemon]
+		for _, s := range species {
+			for _, v := range s.Varieties {
+				res = append(res, v.Pokemon)
+			}
+		}
+		return res, nil
+	}
+}
+
+// SpeciesInGeneration matches pokeapi.PokemonSpecies introduced in the named
+// pokeapi.Generation. Its Source is pokeapi.Generation.PokemonSpecies.
+func SpeciesInGeneration(name string) Predicate[pokeapi.PokemonSpecies] {
+	return Predicate[pokeapi.PokemonSpecies]{
+		Match: func(_ context.Context, _ *pokeapi.Client, s *pokeapi.PokemonSpecies) (bool, error) {
+			return s.Generation.Name == name, nil
+		},
+		Source: sourceOf(
+			pokeapi.GenerationResource, name,
+			func(g *pokeapi.Generation) []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies] {
+				return g.PokemonSpecies
+			},
+		),
+	}
+}
+
+// SpeciesInEggGroup matches pokeapi.PokemonSpecies in the named
+// pokeapi.EggGroup. Its Source is pokeapi.EggGroup.PokemonSpecies.
+func SpeciesInEggGroup(name string) Predicate[pokeapi.PokemonSpecies] {
+	return Predicate[pokeapi.PokemonSpecies]{
+		Match: func(_ context.Context, _ *pokeapi.Client, s *pokeapi.PokemonSpecies) (bool, error) {
+			return slices.ContainsFunc(
+				s.EggGroups,
+				func(eg pokeapi.NamedAPIResource[pokeapi.EggGroup]) bool { return eg.Name == name },
+			), nil
+		},
+		Source: sourceOf(
+			pokeapi.EggGroupResource, name,
+			func(eg *pokeapi.EggGroup) []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies] {
+				return eg.PokemonSpecies
+			},
+		),
+	}
+}
+
+// SpeciesIsLegendary matches legendary pokeapi.PokemonSpecies.
+func SpeciesIsLegendary() Predicate[pokeapi.PokemonSpecies] {
+	return Func(func(s *pokeapi.PokemonSpecies) bool { return s.IsLegendary })
+}
+
+// SpeciesIsMythical matches mythical pokeapi.PokemonSpecies.
+func SpeciesIsMythical() Predicate[pokeapi.PokemonSpecies] {
+	return Func(func(s *pokeapi.PokemonSpecies) bool { return s.IsMythical })
+}
+
+// SpeciesCaptureRate matches pokeapi.PokemonSpecies whose capture rate compares
+// to value using the Op.
+func SpeciesCaptureRate(op Op, value int) Predicate[pokeapi.PokemonSpecies] {
+	return Func(func(s *pokeapi.PokemonSpecies) bool { return op.compare(int(s.CaptureRate), value) })
+}
+
+// MoveHasType matches pokeapi.Move s of the named pokeapi.Type. Its Source is
+// pokeapi.Type.Moves.
+func MoveHasType(name string) Predicate[pokeapi.Move] {
+	return Predicate[pokeapi.Move]{
+		Match: func(_ context.Context, _ *pokeapi.Client, m *pokeapi.Move) (bool, error) {
+			return m.Type.Name == name, nil
+		},
+		Source: sourceOf(
+			pokeapi.TypeResource, name,
+			func(t *pokeapi.Type) []pokeapi.NamedAPIResource[pokeapi.Move] { return t.Moves },
+		),
+	}
+}
+
+// MoveHasDamageClass matches pokeapi.Move s of the named
+// pokeapi.MoveDamageClass. Its Source is pokeapi.MoveDamageClass.Moves.
+func MoveHasDamageClass(name string) Predicate[pokeapi.Move] {
+	return Predicate[pokeapi.Move]{
+		Match: func(_ context.Context, _ *pokeapi.Client, m *pokeapi.Move) (bool, error) {
+			return m.DamageClass.Name == name, nil
+		},
+		Source: sourceOf(
+			pokeapi.MoveDamageClassResource, name,
+			func(dc *pokeapi.MoveDamageClass) []pokeapi.NamedAPIResource[pokeapi.Move] { return dc.Moves },
+		),
+	}
+}
+
+// MoveInGeneration matches pokeapi.Move s introduced in the named
+// pokeapi.Generation. Its Source is pokeapi.Generation.Moves.
+func MoveInGeneration(name string) Predicate[pokeapi.Move] {
+	return Predicate[pokeapi.Move]{
+		Match: func(_ context.Context, _ *pokeapi.Client, m *pokeapi.Move) (bool, error) {
+			return m.Generation.Name == name, nil
+		},
+		Source: sourceOf(
+			pokeapi.GenerationResource, name,
+			func(g *pokeapi.Generation) []pokeapi.NamedAPIResource[pokeapi.Move] { return g.Moves },
+		),
+	}
+}
+
+// MovePower matches pokeapi.Move s whose power compares to value using the Op.
+// Moves without a power never match.
+func MovePower(op Op, value int) Predicate[pokeapi.Move] {
+	return Func(func(m *pokeapi.Move) bool { return m.Power != nil && op.compare(*m.Power, value) })
+}
+
+// MoveAccuracy matches pokeapi.Move s whose accuracy compares to value using
+// the Op. Moves that never miss have no accuracy, and never match.
+func MoveAccuracy(op Op, value int) Predicate[pokeapi.Move] {
+	return Func(func(m *pokeapi.Move) bool { return m.Accuracy != nil && op.compare(*m.Accuracy, value) })
+}
@@ -0,0 +1,235 @@
+//go:build go1.23
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/iterator"
+)
+
+// A Source lists the resources that could match a Predicate. Every resource
+// that matches must be in the list, but not every resource in the list need
+// match.
+type Source[T any] func(ctx context.Context, c *pokeapi.Client) ([]pokeapi.NamedAPIResource[T], error)
+
+// A Predicate decides whether a resource matches. The zero value matches
+// everything.
+type Predicate[T any] struct {
+	// Match reports whether the resource matches. It may use the pokeapi.Client
+	// to retrieve related resources.
+	Match func(ctx context.Context, c *pokeapi.Client, v *T) (bool, error)
+
+	// Source optionally narrows the resources to check. If nil, every resource
+	// at the Query's pokeapi.ResourceName is checked.
+	Source Source[T]
+}
+
+func (p Predicate[T]) match(ctx context.Context, c *pokeapi.Client, v *T) (bool, error) {
+	if p.Match == nil {
+		return true, nil
+	}
+	return p.Match(ctx, c, v)
+}
+
+// Func returns a Predicate matching the resources fn returns true for.
+func Func[T any](fn func(v *T) bool) Predicate[T] {
+	return Predicate[T]{
+		Match: func(_ context.Context, _ *pokeapi.Client, v *T) (bool, error) { return fn(v), nil },
+	}
+}
+
+// All returns a Predicate matching the resources that match every one of the
+// Predicate s. Its Source is the intersection of theirs.
+func All[T any](preds ...Predicate[T]) Predicate[T] {
+	var sources []Source[T]
+	for _, p := range preds {
+		if p.Source != nil {
+			sources = append(sources, p.Source)
+		}
+	}
+
+	res := Predicate[T]{
+		Match: func(ctx context.Context, c *pokeapi.Client, v *T) (bool, error) {
+			for _, p := range preds {
+				if ok, err := p.match(ctx, c, v); !ok || err != nil {
+					return false, err
+				}
+			}
+			return true, nil
+		},
+	}
+	if len(sources) != 0 {
+		res.Source = intersect(sources)
+	}
+	return res
+}
+
+// Any returns a Predicate matching the resources that match at least one of the
+// Predicate s. It has a Source only if every one of them does: the union of
+// theirs.
+func Any[T any](preds ...Predicate[T]) Predicate[T] {
+	res := Predicate[T]{
+		Match: func(ctx context.Context, c *pokeapi.Client, v *T) (bool, error) {
+			for _, p := range preds {
+				if ok, err := p.match(ctx, c, v); ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		},
+	}
+
+	sources := make([]Source[T], len(preds))
+	for i, p := range preds {
+		if p.Source == nil {
+			return res
+		}
+		sources[i] = p.Source
+	}
+	res.Source = union(sources)
+	return res
+}
+
+// Not returns a Predicate matching the resources that do not match the
+// Predicate. It has no Source.
+func Not[T any](p Predicate[T]) Predicate[T] {
+	return Predicate[T]{
+		Match: func(ctx context.Context, c *pokeapi.Client, v *T) (bool, error) {
+			ok, err := p.match(ctx, c, v)
+			return !ok && err == nil, err
+		},
+	}
+}
+
+func intersect[T any](sources []Source[T]) Source[T] {
+	return func(ctx context.Context, c *pokeapi.Client) ([]pokeapi.NamedAPIResource[T], error) {
+		var res []pokeapi.NamedAPIResource[T]
+		for i, s := range sources {
+			refs, err := s(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				res = dedupe(refs)
+				continue
+			}
+
+			names := make(map[string]bool, len(refs))
+			for _, r := range refs {
+				names[r.Name] = true
+			}
+			res = slices.DeleteFunc(res, func(r pokeapi.NamedAPIResource[T]) bool { return !names[r.Name] })
+		}
+		return res, nil
+	}
+}
+
+func union[T any](sources []Source[T]) Source[T] {
+	return func(ctx context.Context, c *pokeapi.Client) ([]pokeapi.NamedAPIResource[T], error) {
+		var res []pokeapi.NamedAPIResource[T]
+		for _, s := range sources {
+			refs, err := s(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, refs...)
+		}
+		return dedupe(res), nil
+	}
+}
+
+func dedupe[T any](refs []pokeapi.NamedAPIResource[T]) []pokeapi.NamedAPIResource[T] {
+	var (
+		seen = make(map[string]bool, len(refs))
+		res  = make([]pokeapi.NamedAPIResource[T], 0, len(refs))
+	)
+	for _, r := range refs {
+		if !seen[r.Name] {
+			seen[r.Name] = true
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// A Query finds every resource at a pokeapi.ResourceName matching all of its
+// Predicate s.
+type Query[T any] struct {
+	resource pokeapi.ResourceName[pokeapi.NamedAPIResource[T], T]
+	pred     Predicate[T]
+}
+
+// New returns a Query for the resources at the pokeapi.ResourceName matching
+// every one of the Predicate s.
+func New[T any](resource pokeapi.ResourceName[pokeapi.NamedAPIResource[T], T], preds ...Predicate[T]) *Query[T] {
+	return &Query[T]{resource: resource, pred: All(preds...)}
+}
+
+// Where returns a copy of the Query that must also match the Predicate s.
+func (q *Query[T]) Where(preds ...Predicate[T]) *Query[T] {
+	return &Query[T]{resource: q.resource, pred: All(append([]Predicate[T]{q.pred}, preds...)...)}
+}
+
+// Run returns an iter.Seq2 that yields every resource matching the Query, as
+// each is found.
+//
+// If the Query has a Source, only the resources it lists are retrieved, in the
+// order it lists them. Otherwise every resource at the pokeapi.ResourceName is
+// retrieved, in list order.
+//
+// If an error occurs (including the cancellation of ctx), it is yielded
+// alongside a nil resource and iteration ends.
+func (q *Query[T]) Run(ctx context.Context, c *pokeapi.Client) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for v, err := range q.candidates(ctx, c) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			ok, err := q.pred.match(ctx, c, v)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if ok && !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (q *Query[T]) candidates(ctx context.Context, c *pokeapi.Client) iter.Seq2[*T, error] {
+	if q.pred.Source == nil {
+		return iterator.Seq(ctx, c, q.resource)
+	}
+
+	return func(yield func(*T, error) bool) {
+		refs, err := q.pred.Source(ctx, c)
+		if err != nil {
+			yield(nil, fmt.Errorf("listing candidates: %w", err))
+			return
+		}
+
+		for _, r := range refs {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			v, err := r.Get(ctx, c)
+			if err != nil {
+				yield(nil, fmt.Errorf("getting %q: %w", r.Name, err))
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,284 @@
+//go:build go1.23
+
+package query_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/iterator"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+	"github.com/nightmarlin/pokeapi/query"
+	"github.com/nightmarlin/pokeapi/stats"
+)
+
+// server serves a handful of pokemon, their species, the fire & flying types
+// and the dragon egg group, and counts the requests made for each path.
+func server(t *testing.T) (*pokeapi.Client, func(path string) int) {
+	t.Helper()
+
+	ts, c := pokeapitest.NewServer(t)
+
+	ref := func(name string) pokeapi.NamedAPIResource[pokeapi.Pokemon] {
+		return pokeapi.NamedAPIResource[pokeapi.Pokemon]{
+			APIResource: pokeapi.APIResource[pokeapi.Pokemon]{URL: ts.URL + "/pokemon/" + name + "/"},
+			Name:        name,
+		}
+	}
+	pokemon := func(name string, speed int, types ...string) pokeapi.Pokemon {
+		p := pokeapi.Pokemon{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+			Species: pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+				APIResource: pokeapi.APIResource[pokeapi.PokemonSpecies]{URL: ts.URL + "/pokemon-species/" + name + "/"},
+				Name:        name,
+			},
+			Stats: []pokeapi.PokemonStat{
+				{Stat: pokeapi.NamedAPIResource[pokeapi.Stat]{Name: stats.Speed}, BaseStat: speed},
+			},
+		}
+		for i, tn := range types {
+			p.Types = append(
+				p.Types,
+				pokeapi.PokemonType{Slot: i + 1, Type: pokeapi.NamedAPIResource[pokeapi.Type]{Name: tn}},
+			)
+		}
+		return p
+	}
+
+	ts.Add(
+		"/pokemon/",
+		pokeapi.Page[pokeapi.NamedAPIResource[pokeapi.Pokemon], pokeapi.Pokemon]{
+			Count: 4,
+			Results: []pokeapi.NamedAPIResource[pokeapi.Pokemon]{
+				ref("bulbasaur"), ref("charmander"), ref("charizard"), ref("pidgey"),
+			},
+		},
+	)
+	ts.Add("/pokemon/bulbasaur/", pokemon("bulbasaur", 45, "grass", "poison"))
+	ts.Add("/pokemon/charmander/", pokemon("charmander", 65, "fire"))
+	ts.Add("/pokemon/charizard/", pokemon("charizard", 100, "fire", "flying"))
+	ts.Add("/pokemon/pidgey/", pokemon("pidgey", 56, "normal", "flying"))
+	ts.Add(
+		"/type/fire/",
+		pokeapi.Type{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: "fire"},
+			Pokemon: []pokeapi.TypePokemon{
+				{Slot: 1, Pokemon: ref("charmander")},
+				{Slot: 1, Pokemon: ref("charizard")},
+			},
+		},
+	)
+	ts.Add(
+		"/type/flying/",
+		pokeapi.Type{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: "flying"},
+			Pokemon: []pokeapi.TypePokemon{
+				{Slot: 2, Pokemon: ref("charizard")},
+				{Slot: 2, Pokemon: ref("pidgey")},
+			},
+		},
+	)
+
+	speciesRefs := make(map[string]pokeapi.NamedAPIResource[pokeapi.PokemonSpecies])
+	for name, eggGroups := range map[string][]string{
+		"bulbasaur":  {"monster", "plant"},
+		"charmander": {"monster", "dragon"},
+		"charizard":  {"monster", "dragon"},
+		"pidgey":     {"flying"},
+	} {
+		s := pokeapi.PokemonSpecies{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+			Varieties:       []pokeapi.PokemonSpeciesVariety{{IsDefault: true, Pokemon: ref(name)}},
+		}
+		for _, eg := range eggGroups {
+			s.EggGroups = append(s.EggGroups, pokeapi.NamedAPIResource[pokeapi.EggGroup]{Name: eg})
+		}
+		speciesRefs[name] = pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+			APIResource: pokeapi.APIResource[pokeapi.PokemonSpecies]{URL: ts.Add("/pokemon-species/"+name+"/", s)},
+			Name:        name,
+		}
+	}
+	ts.Add(
+		"/egg-group/dragon/",
+		pokeapi.EggGroup{
+			NamedIdentifier: pokeapi.NamedIdentifier{Name: "dragon"},
+			PokemonSpecies:  []pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{speciesRefs["charmander"], speciesRefs["charizard"]},
+		},
+	)
+
+	return c, ts.Requests
+}
+
+// names runs the Query, and returns the names of the pokemon it finds.
+func names(t *testing.T, c *pokeapi.Client, q *query.Query[pokeapi.Pokemon]) []string {
+	t.Helper()
+
+	ps, err := iterator.Collect(q.Run(context.Background(), c))
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	res := make([]string, len(ps))
+	for i, p := range ps {
+		res[i] = p.Name
+	}
+	return res
+}
+
+func TestQuery_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run(
+		"retrieves only the resources listed by the source",
+		func(t *testing.T) {
+			t.Parallel()
+
+			c, requests := server(t)
+			got := names(
+				t, c,
+				query.New(
+					pokeapi.PokemonResource,
+					query.PokemonHasType("fire"),
+					query.PokemonBaseStat(stats.Speed, query.Ge, 100),
+				),
+			)
+
+			if !slices.Equal(got, []string{"charizard"}) {
+				t.Errorf("want [charizard]; got %v", got)
+			}
+			if n := requests("/pokemon/"); n != 0 {
+				t.Errorf("want the pokemon list not to be requested; got %d requests", n)
+			}
+			if n := requests("/pokemon/bulbasaur/"); n != 0 {
+				t.Errorf("want bulbasaur not to be requested; got %d requests", n)
+			}
+		},
+	)
+
+	t.Run(
+		"intersects the sources of every predicate",
+		func(t *testing.T) {
+			t.Parallel()
+
+			c, requests := server(t)
+			got := names(
+				t, c,
+				query.New(
+					pokeapi.PokemonResource,
+					query.PokemonHasType("fire"),
+					query.PokemonHasType("flying"),
+				),
+			)
+
+			if !slices.Equal(got, []string{"charizard"}) {
+				t.Errorf("want [charizard]; got %v", got)
+			}
+			if n := requests("/pokemon/charmander/") + requests("/pokemon/pidgey/"); n != 0 {
+				t.Errorf("want only charizard to be requested; got %d other requests", n)
+			}
+		},
+	)
+
+	t.Run(
+		"unions the sources of Any",
+		func(t *testing.T) {
+			t.Parallel()
+
+			c, _ := server(t)
+			got := names(
+				t, c,
+				query.New(
+					pokeapi.PokemonResource,
+					query.Any(query.PokemonHasType("fire"), query.PokemonHasType("flying")),
+				),
+			)
+
+			if !slices.Equal(got, []string{"charmander", "charizard", "pidgey"}) {
+				t.Errorf("want [charmander charizard pidgey]; got %v", got)
+			}
+		},
+	)
+
+	t.Run(
+		"narrows pokemon by the source of their species' predicate",
+		func(t *testing.T) {
+			t.Parallel()
+
+			c, requests := server(t)
+			got := names(t, c, query.New(pokeapi.PokemonResource, query.PokemonSpecies(query.SpeciesInEggGroup("dragon"))))
+
+			if !slices.Equal(got, []string{"charmander", "charizard"}) {
+				t.Errorf("want [charmander charizard]; got %v", got)
+			}
+			if n := requests("/pokemon/"); n != 0 {
+				t.Errorf("want the pokemon list not to be requested; got %d requests", n)
+			}
+			if n := requests("/pokemon/bulbasaur/") + requests("/pokemon-species/pidgey/"); n != 0 {
+				t.Errorf("want only dragon egg group pokemon to be requested; got %d other requests", n)
+			}
+		},
+	)
+
+	t.Run(
+		"walks the resource list without a source",
+		func(t *testing.T) {
+			t.Parallel()
+
+			c, requests := server(t)
+			got := names(
+				t, c,
+				query.New(
+					pokeapi.PokemonResource,
+					query.Not(query.PokemonHasType("fire")),
+					query.PokemonBaseStat(stats.Speed, query.Lt, 50),
+				),
+			)
+
+			if !slices.Equal(got, []string{"bulbasaur"}) {
+				t.Errorf("want [bulbasaur]; got %v", got)
+			}
+			if n := requests("/pokemon/"); n == 0 {
+				t.Error("want the pokemon list to be requested; got no requests")
+			}
+		},
+	)
+
+	t.Run(
+		"stops when the caller stops",
+		func(t *testing.T) {
+			t.Parallel()
+
+			c, requests := server(t)
+			for p, err := range query.New(pokeapi.PokemonResource).Run(context.Background(), c) {
+				if err != nil {
+					t.Fatalf("want no error; got %v", err)
+				}
+				if p.Name != "bulbasaur" {
+					t.Errorf("want bulbasaur first; got %s", p.Name)
+				}
+				break
+			}
+
+			if n := requests("/pokemon/pidgey/"); n != 0 {
+				t.Errorf("want pidgey not to be requested; got %d requests", n)
+			}
+		},
+	)
+
+	t.Run(
+		"yields source errors",
+		func(t *testing.T) {
+			t.Parallel()
+
+			c, _ := server(t)
+			_, err := iterator.Collect(
+				query.New(pokeapi.PokemonResource, query.PokemonHasType("shadow")).Run(context.Background(), c),
+			)
+
+			if err == nil {
+				t.Error("want an error for an unknown type; got nil")
+			}
+		},
+	)
+}
@@ -0,0 +1,352 @@
+// Package search finds resources by what users type, rather than by the exact
+// kebab-case names PokéAPI requires - so "mr mime", "Mr. Mime" and "mr mine" all
+// find mr-mime, and "flabebe" finds flabébé.
+//
+// An Index is built from the List pages of any pokeapi.ResourceName, and can
+// also hold the localized pokeapi.Name s of each resource. Queries match terms
+// exactly, by prefix, or within a few typos, ignoring case, accents, spaces
+// and punctuation. An Index can be stored as JSON, to avoid rebuilding it.
+package search
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// A MatchKind is how a query matched a term.
+type MatchKind int
+
+// The kinds of match, from best to worst.
+const (
+	Exact MatchKind = iota + 1
+	Prefix
+	Fuzzy
+)
+
+func (k MatchKind) String() string {
+	switch k {
+	case Exact:
+		return "exact"
+	case Prefix:
+		return "prefix"
+	case Fuzzy:
+		return "fuzzy"
+	default:
+		return fmt.Sprintf("MatchKind(%d)", int(k))
+	}
+}
+
+// A Match is a resource matching a query.
+type Match[T any] struct {
+	Resource pokeapi.NamedAPIResource[T]
+	Term     string    // The term that matched, as it was added.
+	Kind     MatchKind // How the Term matched.
+	Score    float64   // In (0, 1]. Higher is better.
+}
+
+type entry[T any] struct {
+	Resource pokeapi.NamedAPIResource[T] `json:"resource"`
+	Terms    []string                    `json:"terms"`
+
+	normalized []string // Terms, normalized.
+}
+
+// An Index holds the terms each resource can be found by. The zero value is an
+// empty Index ready for use. It is safe for concurrent use.
+type Index[T any] struct {
+	mux     sync.RWMutex
+	entries []*entry[T]
+	byName  map[string]*entry[T]
+}
+
+// Build lists every resource of the pokeapi.ResourceName using the
+// pokeapi.Client, and returns an Index of their names.
+//
+//	ix, err := search.Build(ctx, c, pokeapi.PokemonResource)
+func Build[T any](
+	ctx context.Context,
+	c *pokeapi.Client,
+	rn pokeapi.ResourceName[pokeapi.NamedAPIResource[T], T],
+) (*Index[T], error) {
+	refs, err := pokeapi.ListAll(ctx, c, rn, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ix Index[T]
+	for _, r := range refs {
+		ix.Add(r)
+	}
+	return &ix, nil
+}
+
+// Add adds the resource to the Index, to be found by its name and any of the
+// terms. Adding a resource again adds the new terms to it.
+func (ix *Index[T]) Add(r pokeapi.NamedAPIResource[T], terms ...string) {
+	ix.mux.Lock()
+	defer ix.mux.Unlock()
+
+	if ix.byName == nil {
+		ix.byName = make(map[string]*entry[T])
+	}
+
+	e, ok := ix.byName[r.Name]
+	if !ok {
+		e = &entry[T]{Resource: r}
+		ix.entries = append(ix.entries, e)
+		ix.byName[r.Name] = e
+		terms = append([]string{r.Name}, terms...)
+	}
+
+	for _, t := range terms {
+		if t != "" && !slices.Contains(e.Terms, t) {
+			e.Terms = append(e.Terms, t)
+			e.normalized = append(e.normalized, Normalize(t))
+		}
+	}
+}
+
+// AddNames adds the localized pokeapi.Name s as terms of the resource.
+func (ix *Index[T]) AddNames(r pokeapi.NamedAPIResource[T], names []pokeapi.Name) {
+	terms := make([]string, len(names))
+	for i, n := range names {
+		terms[i] = n.Name
+	}
+	ix.Add(r, terms...)
+}
+
+// AddLocalizedNames retrieves every resource in the Index using pokeapi.GetAll,
+// and adds the localized pokeapi.Name s returned by namesOf for each.
+//
+//	err := ix.AddLocalizedNames(ctx, c, 8, func(s *pokeapi.PokemonSpecies) []pokeapi.Name { return s.Names })
+func (ix *Index[T]) AddLocalizedNames(
+	ctx context.Context,
+	c *pokeapi.Client,
+	concurrency int,
+	namesOf func(*T) []pokeapi.Name,
+) error {
+	ix.mux.RLock()
+	refs := make([]pokeapi.NamedAPIResource[T], len(ix.entries))
+	for i, e := range ix.entries {
+		refs[i] = e.Resource
+	}
+	ix.mux.RUnlock()
+
+	resources, err := pokeapi.GetAll(ctx, c, refs, concurrency)
+	for i, r := range resources {
+		if r != nil {
+			ix.AddNames(refs[i], namesOf(r))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("getting resources: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of resources in the Index.
+func (ix *Index[T]) Len() int {
+	ix.mux.RLock()
+	defer ix.mux.RUnlock()
+	return len(ix.entries)
+}
+
+// SearchOpts configure Index.Search.
+type SearchOpts struct {
+	// The maximum number of Match es to return. Default 10; negative for no
+	// limit.
+	Limit int
+
+	// The maximum number of typos a Fuzzy match may have. Defaults to a quarter
+	// of the length of the query, rounded up, and at most 3. Negative to disable
+	// Fuzzy matching.
+	MaxTypos int
+}
+
+// Search returns the resources matching the query, best first. Each resource
+// appears once, with its best matching term. A nil SearchOpts uses the
+// defaults.
+//
+// Terms match Exact-ly, as a Prefix, or Fuzzy-ly if they are within a few typos
+// of the query. Typos are counted as the optimal string alignment distance -
+// the Damerau-Levenshtein distance where no substring is edited twice - so
+// inserting, deleting or changing a character, or swapping two neighbouring
+// characters, each count as one.
+func (ix *Index[T]) Search(query string, opts *SearchOpts) []Match[T] {
+	var o SearchOpts
+	if opts != nil {
+		o = *opts
+	}
+	o.Limit = cmp.Or(o.Limit, 10)
+
+	q := []rune(Normalize(query))
+	if len(q) == 0 {
+		return nil
+	}
+	if o.MaxTypos == 0 {
+		o.MaxTypos = min((len(q)+3)/4, 3)
+	}
+
+	ix.mux.RLock()
+	var res []Match[T]
+	for _, e := range ix.entries {
+		best := Match[T]{Resource: e.Resource}
+		for i, t := range e.normalized {
+			if kind, score := match(q, []rune(t), o.MaxTypos); score > best.Score {
+				best.Term, best.Kind, best.Score = e.Terms[i], kind, score
+			}
+		}
+		if best.Score > 0 {
+			res = append(res, best)
+		}
+	}
+	ix.mux.RUnlock()
+
+	slices.SortFunc(
+		res,
+		func(a, b Match[T]) int {
+			return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Resource.Name, b.Resource.Name))
+		},
+	)
+	if o.Limit > 0 && len(res) > o.Limit {
+		res = res[:o.Limit]
+	}
+	return res
+}
+
+// Lookup returns the resource best matching the query, if any does.
+//
+//	if r, ok := ix.Lookup("Mr. Mime"); ok {
+//		p, err := r.Get(ctx, c)
+//	}
+func (ix *Index[T]) Lookup(query string) (pokeapi.NamedAPIResource[T], bool) {
+	res := ix.Search(query, &SearchOpts{Limit: 1})
+	if len(res) == 0 {
+		return pokeapi.NamedAPIResource[T]{}, false
+	}
+	return res[0].Resource, true
+}
+
+// match scores how well the query q matches the term t. Exact matches score 1,
+// Prefix matches score in (0.5, 0.9) by how much of the term they cover, and
+// Fuzzy matches score in (0, 0.5) by how few typos they have.
+func match(q, t []rune, maxTypos int) (MatchKind, float64) {
+	switch {
+	case slices.Equal(q, t):
+		return Exact, 1
+	case len(q) < len(t) && slices.Equal(q, t[:len(q)]):
+		return Prefix, 0.5 + 0.4*float64(len(q))/float64(len(t))
+	case maxTypos < 0 || abs(len(q)-len(t)) > maxTypos:
+		return 0, 0
+	}
+
+	d := distance(q, t)
+	if d > maxTypos {
+		return 0, 0
+	}
+	return Fuzzy, 0.5 * (1 - float64(d)/float64(max(len(q), len(t))+1))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// distance returns the optimal string alignment distance between a and b.
+func distance(a, b []rune) int {
+	// d[i][j] is the distance between a[:i] and b[:j].
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// folds maps the characters that Normalize replaces, rather than keeping or
+// dropping. Accented Latin characters lose their accents, and the gender
+// symbols used by Nidoran become the letters PokéAPI uses for them.
+var folds = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+	'ß': "ss",
+	'♀': "f", '♂': "m",
+}
+
+// Normalize returns the form of s that is compared when searching: lower case,
+// with accents removed and anything other than letters & digits dropped. So
+// "Mr. Mime", "mr mime" and "mr-mime" all normalize to "mrmime", and "Flabébé"
+// to "flabebe".
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if f, ok := folds[r]; ok {
+			b.WriteString(f)
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// MarshalJSON stores every resource in the Index, with its terms.
+func (ix *Index[T]) MarshalJSON() ([]byte, error) {
+	ix.mux.RLock()
+	defer ix.mux.RUnlock()
+
+	entries := ix.entries
+	if entries == nil {
+		entries = []*entry[T]{}
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the contents of the Index with those stored by
+// MarshalJSON.
+func (ix *Index[T]) UnmarshalJSON(b []byte) error {
+	var entries []*entry[T]
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+
+	ix.mux.Lock()
+	ix.entries, ix.byName = nil, nil
+	ix.mux.Unlock()
+
+	for _, e := range entries {
+		ix.Add(e.Resource, e.Terms...)
+	}
+	return nil
+}
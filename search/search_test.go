@@ -0,0 +1,142 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+	"github.com/nightmarlin/pokeapi/search"
+)
+
+func newIndex(t *testing.T) *search.Index[pokeapi.PokemonSpecies] {
+	t.Helper()
+
+	localized := map[string][]string{
+		"mr-mime":   {"Mr. Mime", "Pantimos"},
+		"flabebe":   {"Flabébé", "フラベベ"},
+		"nidoran-f": {"Nidoran♀"},
+	}
+
+	ts, c := pokeapitest.NewServer(t)
+	page := func(next *string, names ...string) pokeapi.Page[pokeapi.NamedAPIResource[pokeapi.PokemonSpecies], pokeapi.PokemonSpecies] {
+		p := pokeapi.Page[pokeapi.NamedAPIResource[pokeapi.PokemonSpecies], pokeapi.PokemonSpecies]{Count: 6, Next: next}
+		for _, n := range names {
+			s := pokeapi.PokemonSpecies{NamedIdentifier: pokeapi.NamedIdentifier{Name: n}}
+			for _, ln := range localized[n] {
+				s.Names = append(s.Names, pokeapi.Name{Name: ln})
+			}
+			p.Results = append(
+				p.Results,
+				pokeapi.NamedAPIResource[pokeapi.PokemonSpecies]{
+					APIResource: pokeapi.APIResource[pokeapi.PokemonSpecies]{URL: ts.Add("/pokemon-species/"+n+"/", s)},
+					Name:        n,
+				},
+			)
+		}
+		return p
+	}
+
+	next := ts.URL + "/pokemon-species/?offset=3&limit=3"
+	ts.Add("/pokemon-species/", page(&next, "mr-mime", "farfetchd", "ho-oh"))
+	ts.Add("/pokemon-species/?offset=3&limit=3", page(nil, "flabebe", "nidoran-f", "pikachu"))
+
+	ix, err := search.Build(context.Background(), c, pokeapi.PokemonSpeciesResource)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	if ix.Len() != 6 {
+		t.Fatalf("want 6 species; got %d", ix.Len())
+	}
+
+	err = ix.AddLocalizedNames(
+		context.Background(), c, 2,
+		func(s *pokeapi.PokemonSpecies) []pokeapi.Name { return s.Names },
+	)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	return ix
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	ix := newIndex(t)
+
+	for _, tc := range []struct {
+		query string
+		want  string
+		kind  search.MatchKind
+	}{
+		{query: "mr mime", want: "mr-mime", kind: search.Exact},
+		{query: "Mr. Mime", want: "mr-mime", kind: search.Exact},
+		{query: "pantimos", want: "mr-mime", kind: search.Exact},
+		{query: "farfetch'd", want: "farfetchd", kind: search.Exact},
+		{query: "ho oh", want: "ho-oh", kind: search.Exact},
+		{query: "Flabébé", want: "flabebe", kind: search.Exact},
+		{query: "フラベベ", want: "flabebe", kind: search.Exact},
+		{query: "Nidoran♀", want: "nidoran-f", kind: search.Exact},
+		{query: "nidoran", want: "nidoran-f", kind: search.Prefix},
+		{query: "pika", want: "pikachu", kind: search.Prefix},
+		{query: "pikahcu", want: "pikachu", kind: search.Fuzzy},
+		{query: "mr mine", want: "mr-mime", kind: search.Fuzzy},
+	} {
+		got := ix.Search(tc.query, nil)
+		if len(got) == 0 || got[0].Resource.Name != tc.want || got[0].Kind != tc.kind {
+			t.Errorf("%q: want %s as the best %s match; got %+v", tc.query, tc.want, tc.kind, got)
+		}
+	}
+
+	if got := ix.Search("zzzz", nil); len(got) != 0 {
+		t.Errorf("want no matches for zzzz; got %+v", got)
+	}
+	if got := ix.Search("pikahcu", &search.SearchOpts{MaxTypos: -1}); len(got) != 0 {
+		t.Errorf("want no matches without fuzzy matching; got %+v", got)
+	}
+
+	got := ix.Search("fla", nil)
+	if len(got) != 1 || got[0].Term != "flabebe" {
+		t.Errorf("want only flabebe, matching its name; got %+v", got)
+	}
+}
+
+func TestIndex_JSON(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(newIndex(t))
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	var ix search.Index[pokeapi.PokemonSpecies]
+	if err := json.Unmarshal(b, &ix); err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	if ix.Len() != 6 {
+		t.Errorf("want 6 species; got %d", ix.Len())
+	}
+	r, ok := ix.Lookup("flabébé")
+	if !ok || r.Name != "flabebe" || r.URL == "" {
+		t.Errorf("want (flabebe with its url, true); got (%+v, %t)", r, ok)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"Mr. Mime":   "mrmime",
+		"ho-oh":      "hooh",
+		"Flabébé":    "flabebe",
+		"Nidoran♂":   "nidoranm",
+		"Porygon-Z":  "porygonz",
+		"Type: Null": "typenull",
+	} {
+		if got := search.Normalize(in); got != want {
+			t.Errorf("%q: want %q; got %q", in, want, got)
+		}
+	}
+}
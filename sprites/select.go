@@ -0,0 +1,105 @@
+package sprites
+
+// A Source is somewhere a Pokemon's sprites come from.
+type Source int
+
+const (
+	SourceOfficialArtwork Source = iota + 1 // OtherSources.OfficialArtwork.
+	SourceHome                              // OtherSources.Home.
+	SourceDefault                           // Pokemon.PokemonDefaults.
+	SourceGame                              // The GameSprites selected by Preference.Generation & Preference.VersionGroup.
+	SourceDreamWorld                        // OtherSources.DreamWorld.
+	SourceShowdown                          // OtherSources.Showdown.
+)
+
+// DefaultFallback is the chain of Source s Select walks when
+// Preference.Sources is empty.
+var DefaultFallback = []Source{SourceOfficialArtwork, SourceHome, SourceDefault, SourceGame}
+
+// A Preference describes the sprite Select should look for.
+type Preference struct {
+	Shiny  bool
+	Female bool
+	Back   bool // Sources with no back sprites are skipped.
+
+	// The Source s to try, in order. Defaults to DefaultFallback.
+	Sources []Source
+
+	// The names of the pokeapi.Generation & pokeapi.VersionGroup to use for
+	// SourceGame. If either is empty, SourceGame is skipped.
+	Generation   string
+	VersionGroup string
+}
+
+// Select walks the chain of Source s in the Preference, and returns the first
+// sprite that is Present - or "" if there are none.
+//
+// Female sprites fall back to the default ones within each Source, as described
+// by PokemonDefaults.Front; the official artwork does not differ by gender, so
+// is used for either. Shiny sprites never fall back to non-shiny ones.
+//
+//	url := sprites.Select(p.Sprites, sprites.Preference{Shiny: true})
+func Select(p Pokemon, pref Preference) SpriteURL {
+	sources := pref.Sources
+	if len(sources) == 0 {
+		sources = DefaultFallback
+	}
+
+	for _, s := range sources {
+		if url := p.fromSource(s, pref); url.Present() {
+			return url
+		}
+	}
+	return ""
+}
+
+func (p Pokemon) fromSource(s Source, pref Preference) SpriteURL {
+	switch s {
+	case SourceOfficialArtwork:
+		if pref.Back {
+			return ""
+		}
+		return p.Other.OfficialArtwork.Front(pref.Shiny)
+
+	case SourceHome:
+		if pref.Back {
+			return ""
+		}
+		return p.Other.Home.Front(pref.Shiny, pref.Female)
+
+	case SourceDreamWorld:
+		if pref.Back || pref.Shiny {
+			return ""
+		}
+		if pref.Female {
+			return pick(p.Other.DreamWorld.FrontFemale, p.Other.DreamWorld.FrontDefault)
+		}
+		return p.Other.DreamWorld.FrontDefault
+
+	case SourceDefault:
+		return p.PokemonDefaults.face(pref)
+
+	case SourceShowdown:
+		return PokemonDefaults(p.Other.Showdown).face(pref)
+
+	case SourceGame:
+		if pref.Generation == "" || pref.VersionGroup == "" {
+			return ""
+		}
+		gs := p.Versions.Get(pref.Generation, pref.VersionGroup)
+		if pref.Back {
+			return gs.Back(pref.Shiny, pref.Female)
+		}
+		return gs.Front(pref.Shiny, pref.Female)
+
+	default:
+		return ""
+	}
+}
+
+func (d PokemonDefaults) face(pref Preference) SpriteURL {
+	if pref.Back {
+		return d.Back(pref.Shiny, pref.Female)
+	}
+	return d.Front(pref.Shiny, pref.Female)
+}
@@ -1,7 +1,13 @@
 // Package sprites provides types and helper methods for retrieving the sprite
-// you need.
+// you need. Select picks the best sprite available, and a Store downloads and
+// keeps sprites (and cries) on disk.
 package sprites
 
+import (
+	"encoding/json"
+	"slices"
+)
+
 // SpriteURL stores the URL the given sprite is hosted at. Most URLs are
 // optional and may or may not be Present.
 //
@@ -50,7 +56,7 @@ type (
 	OtherSources struct {
 		DreamWorld      DreamWorld      `json:"dream_world"`
 		Home            Home            `json:"home"`
-		OfficialArtwork OfficialArtwork `json:"official_artwork"`
+		OfficialArtwork OfficialArtwork `json:"official-artwork"`
 		Showdown        Showdown        `json:"showdown"`
 	}
 
@@ -61,10 +67,10 @@ type (
 
 	GameSpritesKey string
 
-	// GameSprites is a map of GameSpritesKey to the URLs.
-	// Acceptable usage is documented on the GameSpritesKey definition itself.
+	// GameSprites is a map of GameSpritesKey to the URLs. Use its methods, such
+	// as GameSprites.Front, rather than indexing it directly.
 	//
-	// Not that some keys may be present in the map but have value nil.
+	// Note that some keys may be present in the map but have value nil.
 	GameSprites map[GameSpritesKey]any
 
 	// GameVersions is a map of pokeapi.VersionGroup (specifically its
@@ -104,7 +110,7 @@ type (
 	}
 )
 
-// Cast to string. Typically optional.
+// Retrieve with GameSprites.URL. Typically optional.
 const (
 	FrontDefault     GameSpritesKey = "front_default"
 	FrontTransparent GameSpritesKey = "front_transparent" // Only present in generation-i & generation-ii.
@@ -114,7 +120,7 @@ const (
 	FrontShinyFemale GameSpritesKey = "front_shiny_female"
 
 	BackDefault     GameSpritesKey = "back_default"
-	BackTransparent GameSpritesKey = "back_transparent" // Only present in generation-ii & generation-ii.
+	BackTransparent GameSpritesKey = "back_transparent" // Only present in generation-i & generation-ii.
 	BackGray        GameSpritesKey = "back_gray"        // Only present in generation-i.
 	BackShiny       GameSpritesKey = "back_shiny"
 	BackFemale      GameSpritesKey = "back_female"
@@ -122,8 +128,198 @@ const (
 )
 
 const (
-	// Animated is only present in generation-v. Cast to a PokemonDefaults.
+	// Animated is only present in generation-v. Retrieve with
+	// GameSprites.Animated.
 	Animated GameSpritesKey = "animated"
-	// Icons is present from generation-vii onwards. Cast to an IconSprites.
+	// Icons is present from generation-vii onwards. Retrieve with
+	// GameSprites.Icons.
 	Icons GameSpritesKey = "icons"
 )
+
+// pick returns the first of the URLs that is Present, or "" if none are.
+func pick(urls ...SpriteURL) SpriteURL {
+	for _, u := range urls {
+		if u.Present() {
+			return u
+		}
+	}
+	return ""
+}
+
+// Front returns the front sprite. Female sprites are only present for Pokémon
+// whose appearance differs by gender, so if a female sprite is requested but
+// not Present, the default one is returned.
+func (d PokemonDefaults) Front(shiny, female bool) SpriteURL {
+	switch {
+	case shiny && female:
+		return pick(d.FrontShinyFemale, SpriteURL(d.FrontShiny))
+	case shiny:
+		return SpriteURL(d.FrontShiny)
+	case female:
+		return pick(d.FrontFemale, SpriteURL(d.FrontDefault))
+	default:
+		return SpriteURL(d.FrontDefault)
+	}
+}
+
+// Back returns the back sprite, in the same way as PokemonDefaults.Front.
+func (d PokemonDefaults) Back(shiny, female bool) SpriteURL {
+	switch {
+	case shiny && female:
+		return pick(d.BackShinyFemale, SpriteURL(d.BackShiny))
+	case shiny:
+		return SpriteURL(d.BackShiny)
+	case female:
+		return pick(d.BackFemale, SpriteURL(d.BackDefault))
+	default:
+		return SpriteURL(d.BackDefault)
+	}
+}
+
+// Front returns the front sprite, in the same way as PokemonDefaults.Front.
+func (h Home) Front(shiny, female bool) SpriteURL {
+	switch {
+	case shiny && female:
+		return pick(h.FrontShinyFemale, h.FrontShiny)
+	case shiny:
+		return h.FrontShiny
+	case female:
+		return pick(h.FrontFemale, h.FrontDefault)
+	default:
+		return h.FrontDefault
+	}
+}
+
+// Front returns the front artwork. There is no female artwork.
+func (o OfficialArtwork) Front(shiny bool) SpriteURL {
+	if shiny {
+		return o.FrontShiny
+	}
+	return o.FrontDefault
+}
+
+// Get returns the GameSprites for the named pokeapi.VersionGroup, in the named
+// pokeapi.Generation - such as Get("generation-iv", "platinum"). It returns nil
+// if there are none. See GameVersions for exceptions to the keys.
+func (gs GameSources) Get(generation, versionGroup string) GameSprites {
+	return gs[generation][versionGroup]
+}
+
+// URL returns the URL stored under the key, or "" if it is not present. It
+// must not be used with the Animated or Icons keys.
+func (gs GameSprites) URL(key GameSpritesKey) SpriteURL {
+	s, _ := gs[key].(string)
+	return SpriteURL(s)
+}
+
+// Front returns the front sprite, in the same way as PokemonDefaults.Front.
+func (gs GameSprites) Front(shiny, female bool) SpriteURL {
+	return gs.defaults().Front(shiny, female)
+}
+
+// Back returns the back sprite, in the same way as PokemonDefaults.Front.
+func (gs GameSprites) Back(shiny, female bool) SpriteURL {
+	return gs.defaults().Back(shiny, female)
+}
+
+func (gs GameSprites) defaults() PokemonDefaults {
+	return PokemonDefaults{
+		FrontDefault:     string(gs.URL(FrontDefault)),
+		FrontShiny:       string(gs.URL(FrontShiny)),
+		BackDefault:      string(gs.URL(BackDefault)),
+		BackShiny:        string(gs.URL(BackShiny)),
+		FrontFemale:      gs.URL(FrontFemale),
+		FrontShinyFemale: gs.URL(FrontShinyFemale),
+		BackFemale:       gs.URL(BackFemale),
+		BackShinyFemale:  gs.URL(BackShinyFemale),
+	}
+}
+
+// nested converts the value stored under the key to T. Once decoded from JSON,
+// nested sprite sets are held as a map[string]any.
+func nested[T any](gs GameSprites, key GameSpritesKey) (T, bool) {
+	var res T
+	switch v := gs[key].(type) {
+	case T:
+		return v, true
+	case map[string]any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return res, false
+		}
+		return res, json.Unmarshal(b, &res) == nil
+	default:
+		return res, false
+	}
+}
+
+// Animated returns the animated sprites, which are only present in
+// generation-v.
+func (gs GameSprites) Animated() (PokemonDefaults, bool) {
+	return nested[PokemonDefaults](gs, Animated)
+}
+
+// Icons returns the icon sprites, which are present from generation-vii
+// onwards.
+func (gs GameSprites) Icons() (IconSprites, bool) {
+	return nested[IconSprites](gs, Icons)
+}
+
+// URLs returns every Present URL in the PokemonDefaults.
+func (d PokemonDefaults) URLs() []SpriteURL {
+	return present(
+		SpriteURL(d.FrontDefault), SpriteURL(d.FrontShiny), SpriteURL(d.BackDefault), SpriteURL(d.BackShiny),
+		d.FrontFemale, d.FrontShinyFemale, d.BackFemale, d.BackShinyFemale,
+	)
+}
+
+// URLs returns every Present URL in the GameSprites, including the Animated and
+// Icons sprites.
+func (gs GameSprites) URLs() []SpriteURL {
+	var res []SpriteURL
+	for k := range gs {
+		if u := gs.URL(k); u.Present() {
+			res = append(res, u)
+		}
+	}
+	if a, ok := gs.Animated(); ok {
+		res = append(res, a.URLs()...)
+	}
+	if i, ok := gs.Icons(); ok {
+		res = append(res, present(i.FrontDefault, i.FrontFemale)...)
+	}
+	slices.Sort(res)
+	return res
+}
+
+// URLs returns every Present URL in the Pokemon, from every source.
+func (p Pokemon) URLs() []SpriteURL {
+	res := p.PokemonDefaults.URLs()
+	res = append(res, present(p.Other.DreamWorld.FrontDefault, p.Other.DreamWorld.FrontFemale)...)
+	res = append(
+		res,
+		present(p.Other.Home.FrontDefault, p.Other.Home.FrontShiny, p.Other.Home.FrontFemale, p.Other.Home.FrontShinyFemale)...,
+	)
+	res = append(res, present(p.Other.OfficialArtwork.FrontDefault, p.Other.OfficialArtwork.FrontShiny)...)
+	res = append(res, PokemonDefaults(p.Other.Showdown).URLs()...)
+
+	for _, g := range sortedKeys(p.Versions) {
+		for _, vg := range sortedKeys(p.Versions[g]) {
+			res = append(res, p.Versions[g][vg].URLs()...)
+		}
+	}
+	return res
+}
+
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func present(urls ...SpriteURL) []SpriteURL {
+	return slices.DeleteFunc(urls, func(u SpriteURL) bool { return !u.Present() })
+}
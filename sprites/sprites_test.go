@@ -0,0 +1,144 @@
+package sprites_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi/sprites"
+)
+
+// pikachuJSON is a cut-down version of pikachu's sprites, as returned by
+// PokéAPI.
+const pikachuJSON = `{
+	"front_default": "front.png",
+	"front_shiny": "front-shiny.png",
+	"front_female": "front-female.png",
+	"front_shiny_female": null,
+	"back_default": "back.png",
+	"back_shiny": "back-shiny.png",
+	"back_female": null,
+	"back_shiny_female": null,
+	"other": {
+		"dream_world": {"front_default": "dream-world.svg", "front_female": null},
+		"home": {"front_default": "home.png", "front_shiny": null, "front_female": "home-female.png", "front_shiny_female": null},
+		"official-artwork": {"front_default": "artwork.png", "front_shiny": "artwork-shiny.png"},
+		"showdown": {"front_default": "showdown.gif", "front_shiny": null, "back_default": null, "back_shiny": null}
+	},
+	"versions": {
+		"generation-i": {
+			"yellow": {"front_default": "yellow.png", "front_gray": "yellow-gray.png", "back_default": null}
+		},
+		"generation-v": {
+			"black-white": {
+				"front_default": "bw.png",
+				"front_shiny": "bw-shiny.png",
+				"front_female": null,
+				"animated": {"front_default": "bw-animated.gif", "back_default": "bw-animated-back.gif"}
+			}
+		},
+		"generation-vii": {
+			"ultra-sun-ultra-moon": {
+				"front_default": "usum.png",
+				"front_female": "usum-female.png",
+				"icons": {"front_default": "icon.png", "front_female": null}
+			}
+		}
+	}
+}`
+
+func pikachu(t *testing.T) sprites.Pokemon {
+	t.Helper()
+
+	var p sprites.Pokemon
+	if err := json.Unmarshal([]byte(pikachuJSON), &p); err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	return p
+}
+
+func TestGameSprites(t *testing.T) {
+	t.Parallel()
+
+	p := pikachu(t)
+
+	yellow := p.Versions.Get("generation-i", "yellow")
+	if got := yellow.URL(sprites.FrontGray); got != "yellow-gray.png" {
+		t.Errorf("want yellow-gray.png; got %q", got)
+	}
+	if got := yellow.Back(false, false); got.Present() {
+		t.Errorf("want no back sprite in yellow; got %q", got)
+	}
+
+	bw := p.Versions.Get("generation-v", "black-white")
+	if got := bw.Front(true, true); got != "bw-shiny.png" {
+		t.Errorf("want the shiny sprite when there is no shiny female one; got %q", got)
+	}
+	animated, ok := bw.Animated()
+	if !ok || animated.Back(false, false) != "bw-animated-back.gif" {
+		t.Errorf("want (bw-animated-back.gif, true); got (%q, %t)", animated.Back(false, false), ok)
+	}
+
+	usum := p.Versions.Get("generation-vii", "ultra-sun-ultra-moon")
+	if got := usum.Front(false, true); got != "usum-female.png" {
+		t.Errorf("want usum-female.png; got %q", got)
+	}
+	icons, ok := usum.Icons()
+	if !ok || icons.FrontDefault != "icon.png" {
+		t.Errorf("want (icon.png, true); got (%q, %t)", icons.FrontDefault, ok)
+	}
+	if _, ok := usum.Animated(); ok {
+		t.Error("want no animated sprites in ultra-sun-ultra-moon; got some")
+	}
+
+	if got := p.Versions.Get("generation-ix", "scarlet-violet"); got.Front(false, false).Present() {
+		t.Errorf("want no sprites for a missing version group; got %v", got)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	p := pikachu(t)
+
+	for _, tc := range []struct {
+		name string
+		pref sprites.Preference
+		want sprites.SpriteURL
+	}{
+		{name: "default", want: "artwork.png"},
+		{name: "shiny", pref: sprites.Preference{Shiny: true}, want: "artwork-shiny.png"},
+		{name: "back", pref: sprites.Preference{Back: true}, want: "back.png"},
+		{
+			name: "female without artwork",
+			pref: sprites.Preference{Female: true, Sources: []sprites.Source{sprites.SourceHome, sprites.SourceDefault}},
+			want: "home-female.png",
+		},
+		{
+			name: "shiny falls through home",
+			pref: sprites.Preference{Shiny: true, Sources: []sprites.Source{sprites.SourceHome, sprites.SourceDefault}},
+			want: "front-shiny.png",
+		},
+		{
+			name: "game",
+			pref: sprites.Preference{
+				Sources:    []sprites.Source{sprites.SourceGame, sprites.SourceDefault},
+				Generation: "generation-v", VersionGroup: "black-white",
+			},
+			want: "bw.png",
+		},
+		{
+			name: "game without a version group",
+			pref: sprites.Preference{Sources: []sprites.Source{sprites.SourceGame, sprites.SourceShowdown}},
+			want: "showdown.gif",
+		},
+		{
+			name: "nothing present",
+			pref: sprites.Preference{Back: true, Shiny: true, Sources: []sprites.Source{sprites.SourceShowdown}},
+			want: "",
+		},
+	} {
+		if got := sprites.Select(p, tc.pref); got != tc.want {
+			t.Errorf("%s: want %q; got %q", tc.name, tc.want, got)
+		}
+	}
+}
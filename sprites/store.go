@@ -0,0 +1,264 @@
+package sprites
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
+)
+
+const defaultDownloadTimeout = time.Minute
+
+// ErrNotPresent is returned when a Store is asked for a URL that is not
+// Present.
+var ErrNotPresent = errors.New("url not present")
+
+// A Fetcher retrieves the content of the asset at the URL.
+type Fetcher func(ctx context.Context, url string) ([]byte, error)
+
+// HTTPFetcher returns a Fetcher that retrieves assets using the http.Client. A
+// nil http.Client uses http.DefaultClient.
+func HTTPFetcher(c *http.Client) Fetcher {
+	if c == nil {
+		c = http.DefaultClient
+	}
+
+	return func(ctx context.Context, url string) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+
+		resp, err := c.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
+		return io.ReadAll(resp.Body)
+	}
+}
+
+// An AssetSource has assets a Store can Prefetch. pokeapi.Pokemon and
+// pokeapi.PokemonForm are both AssetSource s.
+type AssetSource interface {
+	AssetURLs() []string
+}
+
+// StoreOpts configure a Store.
+type StoreOpts struct {
+	// Retrieves assets that are not yet stored. Default HTTPFetcher(nil).
+	Fetcher Fetcher
+
+	// The maximum number of assets Prefetch retrieves at once. Default 1.
+	Concurrency int
+
+	// The maximum time a single download may take. Default 1 minute.
+	DownloadTimeout time.Duration
+}
+
+// A Store downloads sprites and cries and keeps them on disk, so that each is
+// only downloaded once. It is safe for concurrent use, and concurrent requests
+// for the same URL share a single download - which is not cancelled when any
+// one of the requests is.
+//
+// Assets are stored by the SHA-256 hash of their content, so an asset served
+// from several URLs is only stored once:
+//
+//	<dir>/objects/<hash[:2]>/<hash><ext> - the content of each asset.
+//	<dir>/urls/<hash of url>             - the content hash of the asset at each URL.
+type Store struct {
+	dir         string
+	fetch       Fetcher
+	concurrency int
+	timeout     time.Duration
+
+	ongoing singleflight.Group
+}
+
+// NewStore returns a Store that keeps assets in dir, creating it if needed. A
+// nil StoreOpts uses the defaults.
+func NewStore(dir string, opts *StoreOpts) (*Store, error) {
+	s := &Store{dir: dir, fetch: HTTPFetcher(nil), concurrency: 1, timeout: defaultDownloadTimeout}
+	if opts != nil {
+		if opts.Fetcher != nil {
+			s.fetch = opts.Fetcher
+		}
+		s.concurrency = max(opts.Concurrency, 1)
+		if opts.DownloadTimeout > 0 {
+			s.timeout = opts.DownloadTimeout
+		}
+	}
+
+	for _, d := range []string{s.objectsDir(), s.urlsDir()} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, fmt.Errorf("creating store: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *Store) objectsDir() string { return filepath.Join(s.dir, "objects") }
+func (s *Store) urlsDir() string    { return filepath.Join(s.dir, "urls") }
+
+func hash(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// urlPath is the file holding the content hash of the asset at the URL.
+func (s *Store) urlPath(url string) string {
+	return filepath.Join(s.urlsDir(), hash([]byte(url)))
+}
+
+// objectPath is the file holding the content with the given hash, keeping the
+// extension of the URL it was retrieved from - so that it can be served or
+// opened by type.
+func (s *Store) objectPath(contentHash, url string) string {
+	ext := strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0]))
+	return filepath.Join(s.objectsDir(), contentHash[:2], contentHash+ext)
+}
+
+// Path returns the path of the local copy of the asset at the URL, downloading
+// it first if it is not stored yet. If ctx is cancelled, Path returns
+// immediately, but a download other calls are waiting on continues.
+func (s *Store) Path(ctx context.Context, url string) (string, error) {
+	if !SpriteURL(url).Present() {
+		return "", ErrNotPresent
+	}
+
+	if p, ok := s.lookup(url); ok {
+		return p, nil
+	}
+
+	ch := s.ongoing.DoChan(
+		url,
+		func() (any, error) {
+			// another call may have stored the asset since the lookup above.
+			if p, ok := s.lookup(url); ok {
+				return p, nil
+			}
+
+			// the download is shared by every call waiting on the url, so it must
+			// not be cancelled along with whichever call started it.
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+			defer cancel()
+			return s.download(ctx, url)
+		},
+	)
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	}
+}
+
+// Get returns the content of the asset at the URL, downloading it first if it is
+// not stored yet.
+func (s *Store) Get(ctx context.Context, url string) ([]byte, error) {
+	p, err := s.Path(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(p)
+}
+
+// lookup returns the path of the stored asset at the URL, if there is one.
+func (s *Store) lookup(url string) (string, bool) {
+	b, err := os.ReadFile(s.urlPath(url))
+	if err != nil {
+		return "", false
+	}
+
+	p := s.objectPath(string(b), url)
+	if _, err := os.Stat(p); err != nil {
+		return "", false
+	}
+	return p, true
+}
+
+func (s *Store) download(ctx context.Context, url string) (string, error) {
+	content, err := s.fetch(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("fetching %q: %w", url, err)
+	}
+
+	contentHash := hash(content)
+	p := s.objectPath(contentHash, url)
+	if _, err := os.Stat(p); errors.Is(err, fs.ErrNotExist) {
+		if err := writeFile(p, content); err != nil {
+			return "", fmt.Errorf("storing %q: %w", url, err)
+		}
+	}
+
+	if err := writeFile(s.urlPath(url), []byte(contentHash)); err != nil {
+		return "", fmt.Errorf("storing %q: %w", url, err)
+	}
+	return p, nil
+}
+
+// writeFile writes the file atomically, so that a partially written file is
+// never read.
+func writeFile(name string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// Prefetch downloads every asset of the AssetSource s that is not stored yet.
+// All assets are attempted; the errors of any that fail are joined.
+//
+//	err := store.Prefetch(ctx, pikachu, pikachuForm)
+func (s *Store) Prefetch(ctx context.Context, sources ...AssetSource) error {
+	var urls []string
+	for _, src := range sources {
+		urls = append(urls, src.AssetURLs()...)
+	}
+
+	var (
+		g    errgroup.Group
+		errs = make([]error, len(urls))
+	)
+	g.SetLimit(s.concurrency)
+	for i, url := range urls {
+		g.Go(func() error { _, errs[i] = s.Path(ctx, url); return nil })
+	}
+	_ = g.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,243 @@
+package sprites_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/sprites"
+)
+
+// fakeFetcher serves the content in assets, counting each fetch.
+type fakeFetcher struct {
+	assets  map[string]string
+	fetches atomic.Int64
+}
+
+func (f *fakeFetcher) fetch(_ context.Context, url string) ([]byte, error) {
+	f.fetches.Add(1)
+	content, ok := f.assets[url]
+	if !ok {
+		return nil, fmt.Errorf("no asset at %q", url)
+	}
+	return []byte(content), nil
+}
+
+func newStore(t *testing.T, dir string, assets map[string]string) (*sprites.Store, *fakeFetcher) {
+	t.Helper()
+
+	f := &fakeFetcher{assets: assets}
+	s, err := sprites.NewStore(dir, &sprites.StoreOpts{Fetcher: f.fetch, Concurrency: 4})
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	return s, f
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	assets := map[string]string{
+		"https://example.com/front.png":    "front",
+		"https://example.com/copy.png":     "front",
+		"https://example.com/cries/25.ogg": "pika",
+	}
+
+	t.Run(
+		"downloads each url once",
+		func(t *testing.T) {
+			t.Parallel()
+
+			s, f := newStore(t, t.TempDir(), assets)
+
+			var wg sync.WaitGroup
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					b, err := s.Get(context.Background(), "https://example.com/front.png")
+					if err != nil || string(b) != "front" {
+						t.Errorf("want (front, nil); got (%s, %v)", b, err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := f.fetches.Load(); got != 1 {
+				t.Errorf("want 1 fetch; got %d", got)
+			}
+		},
+	)
+
+	t.Run(
+		"stores assets by content",
+		func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			s, _ := newStore(t, dir, assets)
+
+			a, err := s.Path(context.Background(), "https://example.com/front.png")
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			b, err := s.Path(context.Background(), "https://example.com/copy.png")
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if a != b || !strings.HasSuffix(a, ".png") || !strings.HasPrefix(a, filepath.Join(dir, "objects")) {
+				t.Errorf("want both urls stored at the same .png in the objects dir; got %q and %q", a, b)
+			}
+
+			// a new Store on the same directory does not download again.
+			s, f := newStore(t, dir, assets)
+			if got, err := s.Path(context.Background(), "https://example.com/front.png"); got != a || err != nil {
+				t.Errorf("want (%q, nil); got (%q, %v)", a, got, err)
+			}
+			if got := f.fetches.Load(); got != 0 {
+				t.Errorf("want no fetches; got %d", got)
+			}
+
+			// a missing object is downloaded again.
+			if err := os.Remove(a); err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if got, err := s.Get(context.Background(), "https://example.com/copy.png"); string(got) != "front" || err != nil {
+				t.Errorf("want (front, nil); got (%s, %v)", got, err)
+			}
+		},
+	)
+
+	t.Run(
+		"reports failures",
+		func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newStore(t, t.TempDir(), assets)
+
+			if _, err := s.Path(context.Background(), ""); !errors.Is(err, sprites.ErrNotPresent) {
+				t.Errorf("want ErrNotPresent; got %v", err)
+			}
+			if _, err := s.Path(context.Background(), "https://example.com/missing.png"); err == nil {
+				t.Error("want an error for a missing asset; got nil")
+			}
+		},
+	)
+}
+
+func TestStore_Path_cancellation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		url     = "https://example.com/front.png"
+		started = make(chan struct{})
+		release = make(chan struct{})
+		fetches atomic.Int64
+	)
+	// fetch blocks until released, then fails if its ctx has been cancelled in
+	// the meantime.
+	fetch := func(ctx context.Context, _ string) ([]byte, error) {
+		if fetches.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return []byte("front"), nil
+	}
+
+	s, err := sprites.NewStore(t.TempDir(), &sprites.StoreOpts{Fetcher: fetch})
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() { _, err := s.Path(ctx, url); first <- err }()
+
+	<-started
+	cancel()
+	select {
+	case err := <-first:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("want the cancelled call to return context.Canceled; got %v", err)
+		}
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatal("want the cancelled call to return without waiting for the download")
+	}
+
+	second := make(chan error, 1)
+	go func() { _, err := s.Path(context.Background(), url); second <- err }()
+
+	// give the second call time to join the ongoing download.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if err := <-second; err != nil {
+		t.Errorf("want the second call to succeed; got %v", err)
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("want 1 fetch; got %d", got)
+	}
+}
+
+func TestStore_Prefetch(t *testing.T) {
+	t.Parallel()
+
+	legacy := "https://example.com/cries/legacy/25.ogg"
+	p := pokeapi.Pokemon{
+		Sprites: sprites.Pokemon{
+			PokemonDefaults: sprites.PokemonDefaults{FrontDefault: "https://example.com/front.png"},
+			Other: sprites.OtherSources{
+				OfficialArtwork: sprites.OfficialArtwork{FrontDefault: "https://example.com/artwork.png"},
+			},
+			Versions: sprites.GameSources{
+				"generation-v": {
+					"black-white": {
+						sprites.FrontDefault: "https://example.com/bw.png",
+						sprites.BackDefault:  nil,
+						sprites.Animated:     map[string]any{"front_default": "https://example.com/bw.gif"},
+					},
+				},
+			},
+		},
+		Cries: pokeapi.PokemonCries{Latest: "https://example.com/cries/25.ogg", Legacy: &legacy},
+	}
+	form := pokeapi.PokemonForm{
+		Sprites: sprites.PokemonForm{
+			PokemonDefaults: sprites.PokemonDefaults{FrontDefault: "https://example.com/form.png"},
+		},
+	}
+
+	assets := make(map[string]string)
+	for _, u := range append(p.AssetURLs(), form.AssetURLs()...) {
+		assets[u] = u
+	}
+	if len(assets) != 7 {
+		t.Fatalf("want 7 asset urls; got %v", assets)
+	}
+
+	s, f := newStore(t, t.TempDir(), assets)
+	if err := s.Prefetch(context.Background(), p, form); err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	if got := f.fetches.Load(); got != 7 {
+		t.Errorf("want 7 fetches; got %d", got)
+	}
+
+	delete(assets, "https://example.com/form.png")
+	s, _ = newStore(t, t.TempDir(), assets)
+	if err := s.Prefetch(context.Background(), p, form); err == nil || !strings.Contains(err.Error(), "form.png") {
+		t.Errorf("want an error for form.png; got %v", err)
+	}
+}
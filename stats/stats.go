@@ -0,0 +1,306 @@
+// Package stats calculates the actual in-game stats of a pokeapi.Pokemon from
+// its base stats, level, individual values (IVs), effort values (EVs) and
+// pokeapi.Nature - and estimates IVs from observed stats in reverse.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// The names of the stats used in battle, as used by pokeapi.Stat.
+const (
+	HP             = "hp"
+	Attack         = "attack"
+	Defense        = "defense"
+	SpecialAttack  = "special-attack"
+	SpecialDefense = "special-defense"
+	Speed          = "speed"
+)
+
+// Names lists every stat used in battle, in the order the games display them.
+var Names = []string{HP, Attack, Defense, SpecialAttack, SpecialDefense, Speed}
+
+// Limits on the values accepted by Calculate and CalculateGen12.
+const (
+	MinLevel = 1
+	MaxLevel = 100
+
+	MaxIV       = 31
+	MaxEV       = 255
+	MaxTotalEVs = 510
+
+	MaxDV      = 15
+	MaxStatExp = 65535
+)
+
+const shedinjaHP = 1 // Shedinja's HP is always 1, regardless of level.
+
+var (
+	// ErrInvalidInput is returned when a level, IV, EV, DV or stat experience is
+	// out of range.
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrNoMatchingIVs is returned by EstimateIVs when no IV could produce an
+	// observed stat.
+	ErrNoMatchingIVs = errors.New("no iv produces the observed stat")
+)
+
+// A Spread holds a value for every stat used in battle. It may hold base stats,
+// IVs, EVs or the resulting stats themselves.
+type Spread struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special_attack"`
+	SpecialDefense int `json:"special_defense"`
+	Speed          int `json:"speed"`
+}
+
+func (s *Spread) field(stat string) *int {
+	switch stat {
+	case HP:
+		return &s.HP
+	case Attack:
+		return &s.Attack
+	case Defense:
+		return &s.Defense
+	case SpecialAttack:
+		return &s.SpecialAttack
+	case SpecialDefense:
+		return &s.SpecialDefense
+	case Speed:
+		return &s.Speed
+	default:
+		return nil
+	}
+}
+
+// Get returns the value of the named stat, or false if the stat is not used in
+// battle.
+func (s Spread) Get(stat string) (int, bool) {
+	f := s.field(stat)
+	if f == nil {
+		return 0, false
+	}
+	return *f, true
+}
+
+// Set sets the value of the named stat, returning false if the stat is not used
+// in battle.
+func (s *Spread) Set(stat string, v int) bool {
+	f := s.field(stat)
+	if f == nil {
+		return false
+	}
+	*f = v
+	return true
+}
+
+// Total returns the sum of every stat in the Spread.
+func (s Spread) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpecialAttack + s.SpecialDefense + s.Speed
+}
+
+// Base returns the base stats of the pokeapi.Pokemon.
+func Base(p *pokeapi.Pokemon) Spread {
+	var s Spread
+	for _, ps := range p.Stats {
+		s.Set(ps.Stat.Name, ps.BaseStat)
+	}
+	return s
+}
+
+// EffortYield returns the EVs gained for defeating the pokeapi.Pokemon.
+func EffortYield(p *pokeapi.Pokemon) Spread {
+	var s Spread
+	for _, ps := range p.Stats {
+		s.Set(ps.Stat.Name, ps.Effort)
+	}
+	return s
+}
+
+// NatureModifier returns the percentage the pokeapi.Nature modifies the named
+// stat by: 110 if it is increased, 90 if it is decreased and 100 otherwise. A
+// nil Nature is neutral.
+func NatureModifier(n *pokeapi.Nature, stat string) int {
+	if n == nil || n.IncreasedStat == nil || n.DecreasedStat == nil ||
+		n.IncreasedStat.Name == n.DecreasedStat.Name {
+		return 100
+	}
+
+	switch stat {
+	case n.IncreasedStat.Name:
+		return 110
+	case n.DecreasedStat.Name:
+		return 90
+	default:
+		return 100
+	}
+}
+
+func checkRange(name string, v, lo, hi int) error {
+	if v < lo || v > hi {
+		return fmt.Errorf("%w: %s %d not in [%d, %d]", ErrInvalidInput, name, v, lo, hi)
+	}
+	return nil
+}
+
+func checkSpread(name string, s Spread, hi int) error {
+	for _, stat := range Names {
+		v, _ := s.Get(stat)
+		if err := checkRange(name+" "+stat, v, 0, hi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calcStat implements the stat formula used from generation III onwards.
+func calcStat(p *pokeapi.Pokemon, stat string, base, level, iv, ev int, nature *pokeapi.Nature) int {
+	core := (2*base + iv + ev/4) * level / 100
+	if stat == HP {
+		if p.Name == "shedinja" {
+			return shedinjaHP
+		}
+		return core + level + 10
+	}
+	return (core + 5) * NatureModifier(nature, stat) / 100
+}
+
+// Calculate returns the stats of the pokeapi.Pokemon at the given level, with
+// the given IVs, EVs & pokeapi.Nature, using the formulas from generation III
+// onwards. A nil Nature is neutral.
+func Calculate(p *pokeapi.Pokemon, level int, ivs, evs Spread, nature *pokeapi.Nature) (Spread, error) {
+	if err := checkRange("level", level, MinLevel, MaxLevel); err != nil {
+		return Spread{}, err
+	}
+	if err := checkSpread("iv", ivs, MaxIV); err != nil {
+		return Spread{}, err
+	}
+	if err := checkSpread("ev", evs, MaxEV); err != nil {
+		return Spread{}, err
+	}
+	if total := evs.Total(); total > MaxTotalEVs {
+		return Spread{}, fmt.Errorf("%w: total evs %d exceeds %d", ErrInvalidInput, total, MaxTotalEVs)
+	}
+
+	var (
+		base = Base(p)
+		res  Spread
+	)
+	for _, stat := range Names {
+		b, _ := base.Get(stat)
+		iv, _ := ivs.Get(stat)
+		ev, _ := evs.Get(stat)
+		res.Set(stat, calcStat(p, stat, b, level, iv, ev, nature))
+	}
+	return res, nil
+}
+
+// HPDV returns the HP determinant value used in generations I & II, which is
+// derived from the least significant bit of the other DVs.
+func HPDV(dvs Spread) int {
+	return (dvs.Attack&1)<<3 | (dvs.Defense&1)<<2 | (dvs.Speed&1)<<1 | (dvs.SpecialAttack & 1)
+}
+
+// CalculateGen12 returns the stats of the pokeapi.Pokemon at the given level,
+// with the given determinant values (DVs) and stat experience, using the
+// formulas from generations I & II.
+//
+// Those generations share a single Special DV & stat experience between
+// Special Attack and Special Defense: the SpecialAttack values are used for
+// both, and the SpecialDefense values are ignored. The HP DV is derived from
+// the others, so dvs.HP is ignored too.
+//
+// Base stats are taken from the pokeapi.Pokemon, which reflects the latest
+// generation. A few Pokémon have had their base stats changed since.
+func CalculateGen12(p *pokeapi.Pokemon, level int, dvs, statExp Spread) (Spread, error) {
+	if err := checkRange("level", level, MinLevel, MaxLevel); err != nil {
+		return Spread{}, err
+	}
+	if err := checkSpread("dv", dvs, MaxDV); err != nil {
+		return Spread{}, err
+	}
+	if err := checkSpread("stat experience", statExp, MaxStatExp); err != nil {
+		return Spread{}, err
+	}
+
+	dvs.HP = HPDV(dvs)
+	dvs.SpecialDefense = dvs.SpecialAttack
+	statExp.SpecialDefense = statExp.SpecialAttack
+
+	var (
+		base = Base(p)
+		res  Spread
+	)
+	for _, stat := range Names {
+		b, _ := base.Get(stat)
+		dv, _ := dvs.Get(stat)
+		se, _ := statExp.Get(stat)
+
+		// the games cap the square root of stat experience at 255, so the bonus
+		// is at most 63.
+		core := ((b+dv)*2 + min(255, int(math.Ceil(math.Sqrt(float64(se)))))/4) * level / 100
+		if stat == HP {
+			res.Set(stat, core+level+10)
+		} else {
+			res.Set(stat, core+5)
+		}
+	}
+	return res, nil
+}
+
+// An IVRange is the inclusive range of IVs that could produce an observed stat.
+type IVRange struct {
+	Min, Max int
+}
+
+// EstimateIVs returns the range of IVs that could produce each observed stat,
+// given the level, EVs & pokeapi.Nature of the pokeapi.Pokemon, keyed by stat
+// name. Lower levels and higher EVs tend to give wider ranges.
+//
+// If no IV could produce an observed stat, ErrNoMatchingIVs is returned.
+func EstimateIVs(
+	p *pokeapi.Pokemon,
+	level int,
+	evs Spread,
+	nature *pokeapi.Nature,
+	observed Spread,
+) (map[string]IVRange, error) {
+	if err := checkRange("level", level, MinLevel, MaxLevel); err != nil {
+		return nil, err
+	}
+	if err := checkSpread("ev", evs, MaxEV); err != nil {
+		return nil, err
+	}
+
+	var (
+		base = Base(p)
+		res  = make(map[string]IVRange, len(Names))
+	)
+	for _, stat := range Names {
+		b, _ := base.Get(stat)
+		ev, _ := evs.Get(stat)
+		want, _ := observed.Get(stat)
+
+		r := IVRange{Min: -1, Max: -1}
+		for iv := 0; iv <= MaxIV; iv++ {
+			if calcStat(p, stat, b, level, iv, ev, nature) != want {
+				continue
+			}
+			if r.Min == -1 {
+				r.Min = iv
+			}
+			r.Max = iv
+		}
+		if r.Min == -1 {
+			return nil, fmt.Errorf("%w: %s %d", ErrNoMatchingIVs, stat, want)
+		}
+		res[stat] = r
+	}
+	return res, nil
+}
@@ -0,0 +1,164 @@
+package stats_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/stats"
+)
+
+func newPokemon(name string, base stats.Spread) *pokeapi.Pokemon {
+	p := &pokeapi.Pokemon{NamedIdentifier: pokeapi.NamedIdentifier{Name: name}}
+	for _, stat := range stats.Names {
+		v, _ := base.Get(stat)
+		p.Stats = append(
+			p.Stats,
+			pokeapi.PokemonStat{Stat: pokeapi.NamedAPIResource[pokeapi.Stat]{Name: stat}, BaseStat: v},
+		)
+	}
+	return p
+}
+
+func newNature(increased, decreased string) *pokeapi.Nature {
+	return &pokeapi.Nature{
+		IncreasedStat: &pokeapi.NamedAPIResource[pokeapi.Stat]{Name: increased},
+		DecreasedStat: &pokeapi.NamedAPIResource[pokeapi.Stat]{Name: decreased},
+	}
+}
+
+var (
+	garchomp = newPokemon(
+		"garchomp",
+		stats.Spread{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102},
+	)
+	adamant = newNature(stats.Attack, stats.SpecialAttack)
+
+	// the worked example from bulbapedia's stat article.
+	garchompIVs      = stats.Spread{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5}
+	garchompEVs      = stats.Spread{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23}
+	garchompObserved = stats.Spread{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171}
+)
+
+func TestCalculate(t *testing.T) {
+	t.Parallel()
+
+	t.Run(
+		"matches the worked example",
+		func(t *testing.T) {
+			t.Parallel()
+
+			got, err := stats.Calculate(garchomp, 78, garchompIVs, garchompEVs, adamant)
+			if got != garchompObserved || err != nil {
+				t.Errorf("want (%+v, nil); got (%+v, %v)", garchompObserved, got, err)
+			}
+		},
+	)
+
+	t.Run(
+		"treats a nil or self-cancelling nature as neutral",
+		func(t *testing.T) {
+			t.Parallel()
+
+			neutral, _ := stats.Calculate(garchomp, 50, garchompIVs, garchompEVs, nil)
+			hardy, _ := stats.Calculate(garchomp, 50, garchompIVs, garchompEVs, newNature(stats.Attack, stats.Attack))
+			if neutral != hardy {
+				t.Errorf("want hardy to be neutral (%+v); got %+v", neutral, hardy)
+			}
+		},
+	)
+
+	t.Run(
+		"gives shedinja 1 hp",
+		func(t *testing.T) {
+			t.Parallel()
+
+			shedinja := newPokemon("shedinja", stats.Spread{HP: 1, Attack: 90, Defense: 45, SpecialAttack: 30, SpecialDefense: 30, Speed: 40})
+			got, err := stats.Calculate(shedinja, 100, stats.Spread{HP: 31}, stats.Spread{HP: 252}, nil)
+			if got.HP != 1 || err != nil {
+				t.Errorf("want (HP:1, nil); got (%+v, %v)", got, err)
+			}
+		},
+	)
+
+	t.Run(
+		"rejects out of range inputs",
+		func(t *testing.T) {
+			t.Parallel()
+
+			for name, fn := range map[string]func() error{
+				"level 0": func() error { _, err := stats.Calculate(garchomp, 0, stats.Spread{}, stats.Spread{}, nil); return err },
+				"iv 32": func() error {
+					_, err := stats.Calculate(garchomp, 50, stats.Spread{Speed: 32}, stats.Spread{}, nil)
+					return err
+				},
+				"ev 256": func() error {
+					_, err := stats.Calculate(garchomp, 50, stats.Spread{}, stats.Spread{HP: 256}, nil)
+					return err
+				},
+				"511 total evs": func() error {
+					_, err := stats.Calculate(garchomp, 50, stats.Spread{}, stats.Spread{HP: 255, Attack: 255, Speed: 1}, nil)
+					return err
+				},
+			} {
+				if err := fn(); !errors.Is(err, stats.ErrInvalidInput) {
+					t.Errorf("%s: want ErrInvalidInput; got %v", name, err)
+				}
+			}
+		},
+	)
+}
+
+func TestCalculateGen12(t *testing.T) {
+	t.Parallel()
+
+	mon := newPokemon(
+		"testmon",
+		stats.Spread{HP: 100, Attack: 100, Defense: 100, SpecialAttack: 100, SpecialDefense: 50, Speed: 100},
+	)
+	maxed := stats.Spread{HP: 0, Attack: 15, Defense: 15, SpecialAttack: 15, SpecialDefense: 0, Speed: 15}
+	maxExp := stats.Spread{HP: 65535, Attack: 65535, Defense: 65535, SpecialAttack: 65535, Speed: 65535}
+
+	got, err := stats.CalculateGen12(mon, 100, maxed, maxExp)
+	want := stats.Spread{HP: 403, Attack: 298, Defense: 298, SpecialAttack: 298, SpecialDefense: 198, Speed: 298}
+	if got != want || err != nil {
+		t.Errorf("want (%+v, nil); got (%+v, %v)", want, got, err)
+	}
+
+	// a level 100 mewtwo with max dvs & stat experience, as in red & blue.
+	mewtwo := newPokemon(
+		"mewtwo",
+		stats.Spread{HP: 106, Attack: 110, Defense: 90, SpecialAttack: 154, SpecialDefense: 90, Speed: 130},
+	)
+	got, err = stats.CalculateGen12(mewtwo, 100, maxed, maxExp)
+	want = stats.Spread{HP: 415, Attack: 318, Defense: 278, SpecialAttack: 406, SpecialDefense: 278, Speed: 358}
+	if got != want || err != nil {
+		t.Errorf("mewtwo: want (%+v, nil); got (%+v, %v)", want, got, err)
+	}
+
+	if hp := stats.HPDV(stats.Spread{Attack: 1, Defense: 2, SpecialAttack: 3, Speed: 5}); hp != 0b1011 {
+		t.Errorf("want hp dv 11; got %d", hp)
+	}
+}
+
+func TestEstimateIVs(t *testing.T) {
+	t.Parallel()
+
+	got, err := stats.EstimateIVs(garchomp, 78, garchompEVs, adamant, garchompObserved)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	for _, stat := range stats.Names {
+		iv, _ := garchompIVs.Get(stat)
+		if r := got[stat]; r.Min > iv || r.Max < iv {
+			t.Errorf("want %s range to contain %d; got %+v", stat, iv, r)
+		}
+	}
+
+	impossible := garchompObserved
+	impossible.Speed = 999
+	if _, err := stats.EstimateIVs(garchomp, 78, garchompEVs, adamant, impossible); !errors.Is(err, stats.ErrNoMatchingIVs) {
+		t.Errorf("want ErrNoMatchingIVs; got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+// Package team analyses a team of up to six Pokémon and their chosen moves
+// against a typechart.Chart: which types the team's moves hit super-effectively,
+// which types it leaves uncovered, and which types several members are weak to.
+//
+//	chart, _ := typechart.Build(ctx, c)
+//	pikachu, _ := team.NewMember(ctx, c, "pikachu", "thunderbolt", "iron-tail")
+//	a, _ := team.Analyze(chart, pikachu, ...)
+//	a.Uncovered // [dragon, electric, grass, ...]
+package team
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/typechart"
+)
+
+// MaxSize is the most Pokémon a team can hold.
+const MaxSize = 6
+
+// MoveDamageClassStatus is the name of the pokeapi.MoveDamageClass of moves that
+// deal no damage, which are ignored when calculating coverage.
+const MoveDamageClassStatus = "status"
+
+// ExcludedTypes are the types no Pokémon has, which are left out of every
+// Analysis.
+var ExcludedTypes = []string{"unknown", "shadow", "stellar"}
+
+// ErrInvalidTeam is returned when a team is empty or has more than MaxSize
+// members.
+var ErrInvalidTeam = errors.New("invalid team")
+
+// A Member is a Pokémon on a team, with the moves chosen for it.
+type Member struct {
+	Pokemon *pokeapi.Pokemon
+	Moves   []*pokeapi.Move
+}
+
+// NewMember retrieves the named pokeapi.Pokemon and pokeapi.Move s using the
+// pokeapi.Client - so the Client's pokeapi.Cache is used - and returns them as a
+// Member.
+func NewMember(ctx context.Context, c *pokeapi.Client, pokemon string, moves ...string) (Member, error) {
+	p, err := c.GetPokemon(ctx, pokemon)
+	if err != nil {
+		return Member{}, fmt.Errorf("getting pokemon %q: %w", pokemon, err)
+	}
+
+	m := Member{Pokemon: p, Moves: make([]*pokeapi.Move, len(moves))}
+	for i, name := range moves {
+		if m.Moves[i], err = c.GetMove(ctx, name); err != nil {
+			return Member{}, fmt.Errorf("getting move %q: %w", name, err)
+		}
+	}
+	return m, nil
+}
+
+// MoveCoverage lists the types a Member's move hits super-effectively.
+type MoveCoverage struct {
+	Pokemon string `json:"pokemon"`
+	Move    string `json:"move"`
+	Type    string `json:"type"`
+
+	SuperEffective []string `json:"super_effective"` // In the order of the Chart's types.
+}
+
+// Defense summarises how the team fares against moves of a single attacking
+// type. Each list holds the names of the members, in team order.
+type Defense struct {
+	Type string `json:"type"`
+
+	Weak      []string `json:"weak"`
+	Resistant []string `json:"resistant"`
+	Immune    []string `json:"immune"`
+}
+
+// An Analysis is the result of analysing a team.
+type Analysis struct {
+	// The coverage of every damaging move, in team then move order. Status moves
+	// are left out.
+	Moves []MoveCoverage `json:"moves"`
+
+	// The types hit super-effectively by at least one move, and those hit
+	// super-effectively by none. Both are in the order of the Chart's types.
+	Covered   []string `json:"covered"`
+	Uncovered []string `json:"uncovered"`
+
+	// How the team fares against each attacking type, in the order of the
+	// Chart's types.
+	Defenses []Defense `json:"defenses"`
+}
+
+// SharedWeaknesses returns the Defense s for attacking types that at least two
+// members are weak to, ordered by the number of weak members (most first).
+func (a Analysis) SharedWeaknesses() []Defense {
+	var res []Defense
+	for _, d := range a.Defenses {
+		if len(d.Weak) >= 2 {
+			res = append(res, d)
+		}
+	}
+	slices.SortStableFunc(res, func(a, b Defense) int { return cmp.Compare(len(b.Weak), len(a.Weak)) })
+	return res
+}
+
+// Immunities returns the Defense s for attacking types that at least one member
+// is immune to.
+func (a Analysis) Immunities() []Defense {
+	var res []Defense
+	for _, d := range a.Defenses {
+		if len(d.Immune) != 0 {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// Analyze analyses the team against the typechart.Chart, using the types each
+// Pokémon had in the Chart's generation. Moves are assumed to have the type they
+// have in the latest generation.
+func Analyze(chart *typechart.Chart, members ...Member) (Analysis, error) {
+	if len(members) == 0 || len(members) > MaxSize {
+		return Analysis{}, fmt.Errorf("%w: %d members", ErrInvalidTeam, len(members))
+	}
+
+	types := slices.DeleteFunc(chart.Types(), func(t string) bool { return slices.Contains(ExcludedTypes, t) })
+
+	var (
+		a       Analysis
+		covered = make(map[string]bool, len(types))
+	)
+	for _, m := range members {
+		for _, mv := range m.Moves {
+			if mv.DamageClass.Name == MoveDamageClassStatus {
+				continue
+			}
+
+			mc := MoveCoverage{Pokemon: m.Pokemon.Name, Move: mv.Name, Type: mv.Type.Name}
+			for _, t := range types {
+				e, err := chart.Effectiveness(mv.Type.Name, t)
+				if err != nil {
+					return Analysis{}, fmt.Errorf("move %q: %w", mv.Name, err)
+				}
+				if e > typechart.Effective {
+					mc.SuperEffective = append(mc.SuperEffective, t)
+					covered[t] = true
+				}
+			}
+			a.Moves = append(a.Moves, mc)
+		}
+	}
+
+	for _, t := range types {
+		if covered[t] {
+			a.Covered = append(a.Covered, t)
+		} else {
+			a.Uncovered = append(a.Uncovered, t)
+		}
+	}
+
+	memberTypes := make([][]string, len(members))
+	for i, m := range members {
+		memberTypes[i] = typechart.PokemonTypesAt(m.Pokemon, chart.Generation())
+	}
+
+	for _, t := range types {
+		d := Defense{Type: t}
+		for i, m := range members {
+			e, err := chart.Effectiveness(t, memberTypes[i]...)
+			if err != nil {
+				return Analysis{}, fmt.Errorf("pokemon %q: %w", m.Pokemon.Name, err)
+			}
+			switch {
+			case e == typechart.NoEffect:
+				d.Immune = append(d.Immune, m.Pokemon.Name)
+			case e < typechart.Effective:
+				d.Resistant = append(d.Resistant, m.Pokemon.Name)
+			case e > typechart.Effective:
+				d.Weak = append(d.Weak, m.Pokemon.Name)
+			}
+		}
+		a.Defenses = append(a.Defenses, d)
+	}
+	return a, nil
+}
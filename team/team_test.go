@@ -0,0 +1,125 @@
+package team_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+	"github.com/nightmarlin/pokeapi/team"
+	"github.com/nightmarlin/pokeapi/typechart/typecharttest"
+)
+
+func pokemon(name string, types ...string) *pokeapi.Pokemon {
+	p := &pokeapi.Pokemon{NamedIdentifier: pokeapi.NamedIdentifier{Name: name}}
+	for i, t := range types {
+		p.Types = append(p.Types, pokeapi.PokemonType{Slot: i + 1, Type: pokeapi.NamedAPIResource[pokeapi.Type]{Name: t}})
+	}
+	return p
+}
+
+func move(name, typ, class string) *pokeapi.Move {
+	return &pokeapi.Move{
+		NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+		Type:            pokeapi.NamedAPIResource[pokeapi.Type]{Name: typ},
+		DamageClass:     pokeapi.NamedAPIResource[pokeapi.MoveDamageClass]{Name: class},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	a, err := team.Analyze(
+		typecharttest.Chart(),
+		team.Member{
+			Pokemon: pokemon("pikachu", "electric"),
+			Moves:   []*pokeapi.Move{move("thunderbolt", "electric", "special"), move("growl", "normal", "status")},
+		},
+		team.Member{Pokemon: pokemon("charmander", "fire"), Moves: []*pokeapi.Move{move("ember", "fire", "special")}},
+		team.Member{Pokemon: pokemon("pidgey", "normal", "flying"), Moves: []*pokeapi.Move{move("gust", "flying", "special")}},
+	)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	t.Run(
+		"reports offensive coverage",
+		func(t *testing.T) {
+			t.Parallel()
+
+			want := []team.MoveCoverage{
+				{Pokemon: "pikachu", Move: "thunderbolt", Type: "electric", SuperEffective: []string{"water", "flying"}},
+				{Pokemon: "charmander", Move: "ember", Type: "fire", SuperEffective: []string{"grass", "ice"}},
+				{Pokemon: "pidgey", Move: "gust", Type: "flying", SuperEffective: []string{"grass"}},
+			}
+			if !reflect.DeepEqual(a.Moves, want) {
+				t.Errorf("want %+v; got %+v", want, a.Moves)
+			}
+			if want := []string{"water", "grass", "ice", "flying"}; !reflect.DeepEqual(a.Covered, want) {
+				t.Errorf("want %v covered; got %v", want, a.Covered)
+			}
+			if want := []string{"normal", "fire", "electric", "ground", "psychic", "ghost", "dragon", "fairy"}; !reflect.DeepEqual(a.Uncovered, want) {
+				t.Errorf("want %v uncovered; got %v", want, a.Uncovered)
+			}
+		},
+	)
+
+	t.Run(
+		"reports shared weaknesses and immunities",
+		func(t *testing.T) {
+			t.Parallel()
+
+			ground := team.Defense{Type: "ground", Weak: []string{"pikachu", "charmander"}, Immune: []string{"pidgey"}}
+			if got := a.SharedWeaknesses(); !reflect.DeepEqual(got, []team.Defense{ground}) {
+				t.Errorf("want only ground as a shared weakness; got %+v", got)
+			}
+			ghost := team.Defense{Type: "ghost", Immune: []string{"pidgey"}}
+			if got := a.Immunities(); !reflect.DeepEqual(got, []team.Defense{ground, ghost}) {
+				t.Errorf("want immunities to ground and ghost; got %+v", got)
+			}
+
+			grass := team.Defense{Type: "grass", Resistant: []string{"charmander", "pidgey"}}
+			if got := a.Defenses[3]; !reflect.DeepEqual(got, grass) {
+				t.Errorf("want %+v; got %+v", grass, got)
+			}
+		},
+	)
+}
+
+func TestAnalyze_InvalidTeam(t *testing.T) {
+	t.Parallel()
+
+	if _, err := team.Analyze(typecharttest.Chart()); !errors.Is(err, team.ErrInvalidTeam) {
+		t.Errorf("want ErrInvalidTeam for an empty team; got %v", err)
+	}
+
+	members := make([]team.Member, team.MaxSize+1)
+	for i := range members {
+		members[i] = team.Member{Pokemon: pokemon("magikarp", "water")}
+	}
+	if _, err := team.Analyze(typecharttest.Chart(), members...); !errors.Is(err, team.ErrInvalidTeam) {
+		t.Errorf("want ErrInvalidTeam for %d members; got %v", len(members), err)
+	}
+}
+
+func TestNewMember(t *testing.T) {
+	t.Parallel()
+
+	s, c := pokeapitest.NewServer(t)
+	s.Add("/pokemon/pikachu/", pokemon("pikachu", "electric"))
+	s.Add("/move/thunderbolt/", move("thunderbolt", "electric", "special"))
+
+	m, err := team.NewMember(context.Background(), c, "pikachu", "thunderbolt")
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+	if m.Pokemon.Name != "pikachu" || len(m.Moves) != 1 || m.Moves[0].Name != "thunderbolt" {
+		t.Errorf("want pikachu with thunderbolt; got %+v", m)
+	}
+
+	if _, err := team.NewMember(context.Background(), c, "pikachu", "volt-tackle"); err == nil {
+		t.Error("want an error for an unknown move; got nil")
+	}
+}
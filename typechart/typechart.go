@@ -0,0 +1,345 @@
+// Package typechart computes type effectiveness from the damage relations of
+// every pokeapi.Type. Once built, a Chart answers queries without any further
+// calls to PokéAPI, and can be stored as JSON for use offline.
+//
+// A Chart reflects the latest generation by default. Use Chart.AtGeneration or
+// Chart.AtVersionGroup to get the Chart as it was in an earlier generation -
+// taking into account both changes to damage relations and the introduction of
+// new types.
+package typechart
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/nightmarlin/pokeapi"
+)
+
+// ErrUnknownType is returned when a query refers to a type not in the Chart.
+var ErrUnknownType = fmt.Errorf("unknown type")
+
+// The damage multipliers a type can have against another.
+const (
+	NoEffect         = 0.0
+	NotVeryEffective = 0.5
+	Effective        = 1.0
+	SuperEffective   = 2.0
+)
+
+// relations are the names of the types a type deals modified damage to.
+type relations struct {
+	DoubleDamageTo []string `json:"double_damage_to,omitempty"`
+	HalfDamageTo   []string `json:"half_damage_to,omitempty"`
+	NoDamageTo     []string `json:"no_damage_to,omitempty"`
+}
+
+func relationsOf(tr pokeapi.TypeRelations) relations {
+	names := func(refs []pokeapi.NamedAPIResource[pokeapi.Type]) []string {
+		res := make([]string, len(refs))
+		for i, r := range refs {
+			res[i] = r.Name
+		}
+		return res
+	}
+
+	return relations{
+		DoubleDamageTo: names(tr.DoubleDamageTo),
+		HalfDamageTo:   names(tr.HalfDamageTo),
+		NoDamageTo:     names(tr.NoDamageTo),
+	}
+}
+
+// pastRelations are the relations a type had up to and including Generation.
+type pastRelations struct {
+	Generation int       `json:"generation"`
+	Relations  relations `json:"relations"`
+}
+
+// entry is the information the Chart keeps about each type. It is the unit of
+// the Chart's JSON representation.
+type entry struct {
+	Name       string          `json:"name"`
+	Generation int             `json:"generation,omitempty"` // The generation the type was introduced in, or 0 if unknown.
+	Relations  relations       `json:"relations"`
+	Past       []pastRelations `json:"past,omitempty"`
+}
+
+// at returns the entry as it was in the given generation, and whether the type
+// existed at that point.
+func (e entry) at(gen int) (entry, bool) {
+	if gen == Latest {
+		return e, true
+	}
+	if e.Generation > gen {
+		return entry{}, false
+	}
+
+	// past relations apply up to and including their generation, so the
+	// earliest one at or after gen is the one in effect.
+	var best *pastRelations
+	for i, p := range e.Past {
+		if p.Generation >= gen && (best == nil || p.Generation < best.Generation) {
+			best = &e.Past[i]
+		}
+	}
+	if best != nil {
+		e.Relations = best.Relations
+	}
+	return e, true
+}
+
+// Latest is the generation a Chart reflects by default: the latest one known to
+// PokéAPI.
+const Latest = pokeapi.LatestGeneration
+
+// A Chart holds the damage multiplier of every attacking type against every
+// defending type. It is safe for concurrent use.
+type Chart struct {
+	all        []entry // every type in every generation, used to derive charts for other generations.
+	generation int
+
+	entries     []entry        // the types that exist in generation, with their relations at that point.
+	index       map[string]int // type name => index into entries & multipliers.
+	multipliers [][]float64    // [attacker][defender]
+}
+
+// New builds a Chart from the provided types. Relations referring to types that
+// were not provided are ignored.
+func New(types []*pokeapi.Type) *Chart {
+	entries := make([]entry, len(types))
+	for i, t := range types {
+		entries[i] = entry{
+			Name:       t.Name,
+			Generation: t.Generation.ID(),
+			Relations:  relationsOf(t.DamageRelations),
+		}
+		for _, p := range t.PastDamageRelations {
+			entries[i].Past = append(
+				entries[i].Past,
+				pastRelations{Generation: p.Generation.ID(), Relations: relationsOf(p.DamageRelations)},
+			)
+		}
+	}
+	return newChart(entries, Latest)
+}
+
+func newChart(all []entry, gen int) *Chart {
+	var entries []entry
+	for _, e := range all {
+		if e, ok := e.at(gen); ok {
+			entries = append(entries, e)
+		}
+	}
+
+	c := Chart{
+		all:         all,
+		generation:  gen,
+		entries:     entries,
+		index:       make(map[string]int, len(entries)),
+		multipliers: make([][]float64, len(entries)),
+	}
+
+	for i, e := range entries {
+		c.index[e.Name] = i
+		c.multipliers[i] = make([]float64, len(entries))
+		for j := range c.multipliers[i] {
+			c.multipliers[i][j] = Effective
+		}
+	}
+
+	for i, e := range entries {
+		set := func(defenders []string, m float64) {
+			for _, d := range defenders {
+				if j, ok := c.index[d]; ok {
+					c.multipliers[i][j] = m
+				}
+			}
+		}
+		set(e.Relations.DoubleDamageTo, SuperEffective)
+		set(e.Relations.HalfDamageTo, NotVeryEffective)
+		set(e.Relations.NoDamageTo, NoEffect)
+	}
+
+	return &c
+}
+
+// Build retrieves every pokeapi.Type using the provided pokeapi.Client and
+// builds a Chart from them.
+func Build(ctx context.Context, c *pokeapi.Client) (*Chart, error) {
+	refs, err := pokeapi.ListAll(ctx, c, pokeapi.TypeResource, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := pokeapi.GetAll(ctx, c, refs, pokeapi.DefaultConcurrency)
+	if err != nil {
+		return nil, fmt.Errorf("getting types: %w", err)
+	}
+	return New(types), nil
+}
+
+// AtGeneration returns the Chart as it was in the given generation, identified
+// by its ID - 1 for generation-i and so on. Types introduced after that
+// generation are not present in the returned Chart.
+func (c *Chart) AtGeneration(gen int) (*Chart, error) {
+	if gen < Latest {
+		return nil, fmt.Errorf("invalid generation %d", gen)
+	}
+	return newChart(c.all, gen), nil
+}
+
+// AtVersionGroup returns the Chart as it was in the generation the
+// pokeapi.VersionGroup belongs to.
+func (c *Chart) AtVersionGroup(vg *pokeapi.VersionGroup) (*Chart, error) {
+	gen := vg.Generation.ID()
+	if gen == 0 {
+		return nil, fmt.Errorf("version group %q has no generation", vg.Name)
+	}
+	return c.AtGeneration(gen)
+}
+
+// Generation returns the ID of the generation the Chart reflects, or Latest.
+func (c *Chart) Generation() int { return c.generation }
+
+// Types returns the names of every type in the Chart.
+func (c *Chart) Types() []string {
+	res := make([]string, len(c.entries))
+	for i, e := range c.entries {
+		res[i] = e.Name
+	}
+	return res
+}
+
+func (c *Chart) lookup(name string) (int, error) {
+	i, ok := c.index[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownType, name)
+	}
+	return i, nil
+}
+
+// Effectiveness returns the damage multiplier of a move of the attacking type
+// against a Pokémon with all the defending types - such as 4 for an ice-type
+// move against a dragon/flying-type Pokémon.
+func (c *Chart) Effectiveness(attacker string, defenders ...string) (float64, error) {
+	a, err := c.lookup(attacker)
+	if err != nil {
+		return 0, err
+	}
+
+	m := Effective
+	for _, defender := range defenders {
+		d, err := c.lookup(defender)
+		if err != nil {
+			return 0, err
+		}
+		m *= c.multipliers[a][d]
+	}
+	return m, nil
+}
+
+// A Matchup is the damage multiplier a move of the given Type would have.
+type Matchup struct {
+	Type       string
+	Multiplier float64
+}
+
+// A Profile lists the attacking types that a combination of defending types is
+// weak to, resists and is immune to. Each list is ordered from the most extreme
+// multiplier to the least, then by the order of types in the Chart.
+type Profile struct {
+	Weaknesses  []Matchup // Multiplier > 1.
+	Resistances []Matchup // 0 < Multiplier < 1.
+	Immunities  []Matchup // Multiplier == 0.
+}
+
+// Profile returns the defensive Profile of a Pokémon with the given types.
+func (c *Chart) Profile(defenders ...string) (Profile, error) {
+	var p Profile
+	for _, e := range c.entries {
+		m, err := c.Effectiveness(e.Name, defenders...)
+		if err != nil {
+			return Profile{}, err
+		}
+
+		switch {
+		case m == NoEffect:
+			p.Immunities = append(p.Immunities, Matchup{Type: e.Name, Multiplier: m})
+		case m < Effective:
+			p.Resistances = append(p.Resistances, Matchup{Type: e.Name, Multiplier: m})
+		case m > Effective:
+			p.Weaknesses = append(p.Weaknesses, Matchup{Type: e.Name, Multiplier: m})
+		}
+	}
+
+	slices.SortStableFunc(p.Weaknesses, func(a, b Matchup) int { return cmp.Compare(b.Multiplier, a.Multiplier) })
+	slices.SortStableFunc(p.Resistances, func(a, b Matchup) int { return cmp.Compare(a.Multiplier, b.Multiplier) })
+	return p, nil
+}
+
+// PokemonTypes returns the names of the pokeapi.Pokemon's types, in slot order.
+func PokemonTypes(p *pokeapi.Pokemon) []string {
+	return typeNames(p.Types)
+}
+
+// PokemonTypesAt returns the names of the types the pokeapi.Pokemon had in the
+// given generation, in slot order, taking pokeapi.Pokemon.PastTypes into
+// account.
+func PokemonTypesAt(p *pokeapi.Pokemon, gen int) []string {
+	if gen == Latest {
+		return PokemonTypes(p)
+	}
+
+	// past types apply up to and including their generation, so the earliest
+	// one at or after gen is the one in effect.
+	var best *pokeapi.PokemonTypePast
+	for i, pt := range p.PastTypes {
+		pGen := pt.Generation.ID()
+		if pGen >= gen && (best == nil || pGen < best.Generation.ID()) {
+			best = &p.PastTypes[i]
+		}
+	}
+	if best != nil {
+		return typeNames(best.Types)
+	}
+	return PokemonTypes(p)
+}
+
+func typeNames(pts []pokeapi.PokemonType) []string {
+	sorted := slices.Clone(pts)
+	slices.SortFunc(sorted, func(a, b pokeapi.PokemonType) int { return cmp.Compare(a.Slot, b.Slot) })
+
+	res := make([]string, len(sorted))
+	for i, pt := range sorted {
+		res[i] = pt.Type.Name
+	}
+	return res
+}
+
+// PokemonProfile returns the defensive Profile of the pokeapi.Pokemon, using
+// the types it had in the Chart's generation.
+func (c *Chart) PokemonProfile(p *pokeapi.Pokemon) (Profile, error) {
+	return c.Profile(PokemonTypesAt(p, c.generation)...)
+}
+
+// chartJSON is the JSON representation of a Chart.
+type chartJSON struct {
+	Generation int     `json:"generation,omitempty"`
+	Types      []entry `json:"types"`
+}
+
+func (c *Chart) MarshalJSON() ([]byte, error) {
+	return json.Marshal(chartJSON{Generation: c.generation, Types: c.all})
+}
+
+func (c *Chart) UnmarshalJSON(b []byte) error {
+	var cj chartJSON
+	if err := json.Unmarshal(b, &cj); err != nil {
+		return err
+	}
+	*c = *newChart(cj.Types, cj.Generation)
+	return nil
+}
@@ -0,0 +1,223 @@
+package typechart_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/pokeapitest"
+	"github.com/nightmarlin/pokeapi/typechart"
+	"github.com/nightmarlin/pokeapi/typechart/typecharttest"
+)
+
+func TestChart(t *testing.T) {
+	t.Parallel()
+
+	c := typechart.New(typecharttest.Types())
+
+	t.Run(
+		"computes single and dual type effectiveness",
+		func(t *testing.T) {
+			t.Parallel()
+
+			for _, tc := range []struct {
+				attacker  string
+				defenders []string
+				want      float64
+			}{
+				{attacker: "ice", defenders: []string{"dragon", "flying"}, want: 4},
+				{attacker: "ground", defenders: []string{"flying"}, want: 0},
+				{attacker: "grass", defenders: []string{"dragon", "flying"}, want: 0.25},
+				{attacker: "water", defenders: []string{"fire", "ground"}, want: 4},
+				{attacker: "fire", defenders: []string{"water", "grass"}, want: 1},
+				{attacker: "normal", defenders: []string{"fire"}, want: 1},
+				{attacker: "normal", defenders: nil, want: 1},
+			} {
+				got, err := c.Effectiveness(tc.attacker, tc.defenders...)
+				if got != tc.want || err != nil {
+					t.Errorf("%s vs %v: want (%v, nil); got (%v, %v)", tc.attacker, tc.defenders, tc.want, got, err)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"rejects unknown types",
+		func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := c.Effectiveness("steel", "dragon"); !errors.Is(err, typechart.ErrUnknownType) {
+				t.Errorf("want ErrUnknownType for an unknown attacker; got %v", err)
+			}
+			if _, err := c.Effectiveness("dragon", "steel"); !errors.Is(err, typechart.ErrUnknownType) {
+				t.Errorf("want ErrUnknownType for an unknown defender; got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"profiles a pokemon's weaknesses, resistances and immunities",
+		func(t *testing.T) {
+			t.Parallel()
+
+			dragonite := &pokeapi.Pokemon{
+				Types: []pokeapi.PokemonType{
+					{Slot: 2, Type: typecharttest.Refs("flying")[0]},
+					{Slot: 1, Type: typecharttest.Refs("dragon")[0]},
+				},
+			}
+
+			if got, want := typechart.PokemonTypes(dragonite), []string{"dragon", "flying"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("want types in slot order %v; got %v", want, got)
+			}
+
+			got, err := c.PokemonProfile(dragonite)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+
+			want := typechart.Profile{
+				Weaknesses: []typechart.Matchup{
+					{Type: "ice", Multiplier: 4},
+					{Type: "dragon", Multiplier: 2},
+					{Type: "fairy", Multiplier: 2},
+				},
+				Resistances: []typechart.Matchup{
+					{Type: "grass", Multiplier: 0.25},
+					{Type: "fire", Multiplier: 0.5},
+					{Type: "water", Multiplier: 0.5},
+				},
+				Immunities: []typechart.Matchup{{Type: "ground", Multiplier: 0}},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("want profile %+v; got %+v", want, got)
+			}
+		},
+	)
+
+	t.Run(
+		"reflects past damage relations and types",
+		func(t *testing.T) {
+			t.Parallel()
+
+			gen1, err := c.AtGeneration(1)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if m, err := gen1.Effectiveness("ghost", "psychic"); m != 0 || err != nil {
+				t.Errorf("want ghost vs psychic in generation-i to be (0, nil); got (%v, %v)", m, err)
+			}
+			if m, err := c.Effectiveness("ghost", "psychic"); m != 2 || err != nil {
+				t.Errorf("want ghost vs psychic in the latest generation to be (2, nil); got (%v, %v)", m, err)
+			}
+
+			gen2, err := c.AtVersionGroup(&pokeapi.VersionGroup{Generation: typecharttest.GenerationRef(2)})
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if m, err := gen2.Effectiveness("ghost", "psychic"); m != 2 || err != nil {
+				t.Errorf("want ghost vs psychic in generation-ii to be (2, nil); got (%v, %v)", m, err)
+			}
+			if _, err := gen2.Effectiveness("fairy", "dragon"); !errors.Is(err, typechart.ErrUnknownType) {
+				t.Errorf("want fairy to be unknown in generation-ii; got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"uses the pokemon's types from the chart's generation",
+		func(t *testing.T) {
+			t.Parallel()
+
+			clefairy := &pokeapi.Pokemon{
+				Types: []pokeapi.PokemonType{{Slot: 1, Type: typecharttest.Refs("fairy")[0]}},
+				PastTypes: []pokeapi.PokemonTypePast{
+					{Generation: typecharttest.GenerationRef(5), Types: []pokeapi.PokemonType{{Slot: 1, Type: typecharttest.Refs("normal")[0]}}},
+				},
+			}
+
+			for gen, want := range map[int]string{1: "normal", 5: "normal", 6: "fairy", typechart.Latest: "fairy"} {
+				if got := typechart.PokemonTypesAt(clefairy, gen); len(got) != 1 || got[0] != want {
+					t.Errorf("want clefairy to be [%s] in generation %d; got %v", want, gen, got)
+				}
+			}
+
+			gen5, _ := c.AtGeneration(5)
+			p, err := gen5.PokemonProfile(clefairy)
+			if err != nil {
+				t.Fatalf("want no error; got %v", err)
+			}
+			if len(p.Immunities) != 1 || p.Immunities[0].Type != "ghost" {
+				t.Errorf("want a generation-v clefairy to be immune to ghost; got %+v", p.Immunities)
+			}
+		},
+	)
+
+	t.Run(
+		"round trips through json",
+		func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(c)
+			if err != nil {
+				t.Fatalf("want no error marshalling; got %v", err)
+			}
+
+			var decoded typechart.Chart
+			if err := json.Unmarshal(b, &decoded); err != nil {
+				t.Fatalf("want no error unmarshalling; got %v", err)
+			}
+
+			gen1, _ := decoded.AtGeneration(1)
+			if m, err := gen1.Effectiveness("ghost", "psychic"); m != 0 || err != nil {
+				t.Errorf("want history to survive decoding; got (%v, %v)", m, err)
+			}
+
+			for _, a := range c.Types() {
+				for _, d := range c.Types() {
+					want, _ := c.Effectiveness(a, d)
+					if got, err := decoded.Effectiveness(a, d); got != want || err != nil {
+						t.Errorf("%s vs %s: want (%v, nil) after decoding; got (%v, %v)", a, d, want, got, err)
+					}
+				}
+			}
+		},
+	)
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	var (
+		types = typecharttest.Types()
+		ts, c = pokeapitest.NewServer(t)
+	)
+
+	page := pokeapi.Page[pokeapi.NamedAPIResource[pokeapi.Type], pokeapi.Type]{Count: len(types)}
+	for i, ty := range types {
+		page.Results = append(
+			page.Results,
+			pokeapi.NamedAPIResource[pokeapi.Type]{
+				APIResource: pokeapi.APIResource[pokeapi.Type]{URL: ts.Add(fmt.Sprintf("/type/%d/", i+1), ty)},
+				Name:        ty.Name,
+			},
+		)
+	}
+	ts.Add("/type/?limit=100", page)
+
+	chart, err := typechart.Build(context.Background(), c)
+	if err != nil {
+		t.Fatalf("want no error; got %v", err)
+	}
+
+	if got, want := chart.Types(), typechart.New(types).Types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want chart with types %v; got %v", want, got)
+	}
+	if m, err := chart.Effectiveness("ice", "dragon", "flying"); m != 4 || err != nil {
+		t.Errorf("want (4, nil); got (%v, %v)", m, err)
+	}
+}
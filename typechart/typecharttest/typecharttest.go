@@ -0,0 +1,86 @@
+// Package typecharttest provides a small, realistic set of pokeapi.Type s for
+// testing code that uses a [typechart.Chart], without retrieving the real chart
+// from PokéAPI.
+//
+// As it is intended for tests, typecharttest should not be used in normal
+// application code.
+package typecharttest
+
+import (
+	"fmt"
+
+	"github.com/nightmarlin/pokeapi"
+	"github.com/nightmarlin/pokeapi/typechart"
+)
+
+// Refs returns references to the named types. They have no URL.
+func Refs(names ...string) []pokeapi.NamedAPIResource[pokeapi.Type] {
+	res := make([]pokeapi.NamedAPIResource[pokeapi.Type], len(names))
+	for i, n := range names {
+		res[i] = pokeapi.NamedAPIResource[pokeapi.Type]{Name: n}
+	}
+	return res
+}
+
+// GenerationRef returns a reference to the generation with the ID, as PokéAPI
+// would.
+func GenerationRef(id int) pokeapi.NamedAPIResource[pokeapi.Generation] {
+	return pokeapi.NamedAPIResource[pokeapi.Generation]{
+		APIResource: pokeapi.APIResource[pokeapi.Generation]{
+			URL: fmt.Sprintf("https://pokeapi.co/api/v2/generation/%d/", id),
+		},
+	}
+}
+
+// NewType returns a type dealing double, half and no damage to the named types.
+func NewType(name string, double, half, none []string) *pokeapi.Type {
+	return &pokeapi.Type{
+		NamedIdentifier: pokeapi.NamedIdentifier{Name: name},
+		DamageRelations: pokeapi.TypeRelations{
+			DoubleDamageTo: Refs(double...),
+			HalfDamageTo:   Refs(half...),
+			NoDamageTo:     Refs(none...),
+		},
+	}
+}
+
+// Types returns a subset of the real type chart (as of generation-ix), limited
+// to relations between the types in the subset. It includes some of the chart's
+// history: ghost's generation-i relations and fairy's introduction in
+// generation-vi. The unknown type, which has no relations, is also included.
+//
+// Each call returns new types, which may be modified freely.
+func Types() []*pokeapi.Type {
+	ghost := NewType("ghost", []string{"psychic", "ghost"}, nil, []string{"normal"})
+	ghost.PastDamageRelations = []pokeapi.TypeRelationsPast{
+		{
+			Generation: GenerationRef(1),
+			DamageRelations: pokeapi.TypeRelations{
+				DoubleDamageTo: Refs("ghost"),
+				NoDamageTo:     Refs("normal", "psychic"),
+			},
+		},
+	}
+
+	fairy := NewType("fairy", []string{"dragon"}, []string{"fire"}, nil)
+	fairy.Generation = GenerationRef(6)
+
+	return []*pokeapi.Type{
+		NewType("normal", nil, nil, []string{"ghost"}),
+		NewType("fire", []string{"grass", "ice"}, []string{"fire", "water", "dragon"}, nil),
+		NewType("water", []string{"fire", "ground"}, []string{"water", "grass", "dragon"}, nil),
+		NewType("grass", []string{"water", "ground"}, []string{"fire", "grass", "flying", "dragon"}, nil),
+		NewType("electric", []string{"water", "flying"}, []string{"electric", "grass", "dragon"}, []string{"ground"}),
+		NewType("ice", []string{"grass", "ground", "flying", "dragon"}, []string{"fire", "water", "ice"}, nil),
+		NewType("ground", []string{"fire", "electric"}, []string{"grass"}, []string{"flying"}),
+		NewType("flying", []string{"grass"}, []string{"electric"}, nil),
+		NewType("psychic", nil, []string{"psychic"}, nil),
+		ghost,
+		NewType("dragon", []string{"dragon"}, nil, []string{"fairy"}),
+		fairy,
+		NewType("unknown", nil, nil, nil),
+	}
+}
+
+// Chart returns a typechart.Chart of Types.
+func Chart() *typechart.Chart { return typechart.New(Types()) }